@@ -0,0 +1,398 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: ra/build.proto
+
+package proto
+
+import (
+	gen "github.com/cynxees/cynx-core/proto/gen"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type BuildImageRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Base      *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Kind      string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	BaseImage string                 `protobuf:"bytes,3,opt,name=base_image,json=baseImage,proto3" json:"base_image,omitempty"`
+	// static_ipv4 is an optional CIDR address used instead of DHCP, with ipv4_gateway as its default route
+	StaticIpv4    string `protobuf:"bytes,4,opt,name=static_ipv4,json=staticIpv4,proto3" json:"static_ipv4,omitempty"`
+	Ipv4Gateway   string `protobuf:"bytes,5,opt,name=ipv4_gateway,json=ipv4Gateway,proto3" json:"ipv4_gateway,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildImageRequest) Reset() {
+	*x = BuildImageRequest{}
+	mi := &file_ra_build_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildImageRequest) ProtoMessage() {}
+
+func (x *BuildImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_build_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildImageRequest.ProtoReflect.Descriptor instead.
+func (*BuildImageRequest) Descriptor() ([]byte, []int) {
+	return file_ra_build_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BuildImageRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *BuildImageRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *BuildImageRequest) GetBaseImage() string {
+	if x != nil {
+		return x.BaseImage
+	}
+	return ""
+}
+
+func (x *BuildImageRequest) GetStaticIpv4() string {
+	if x != nil {
+		return x.StaticIpv4
+	}
+	return ""
+}
+
+func (x *BuildImageRequest) GetIpv4Gateway() string {
+	if x != nil {
+		return x.Ipv4Gateway
+	}
+	return ""
+}
+
+type GetBuildRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBuildRequest) Reset() {
+	*x = GetBuildRequest{}
+	mi := &file_ra_build_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBuildRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBuildRequest) ProtoMessage() {}
+
+func (x *GetBuildRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_build_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBuildRequest.ProtoReflect.Descriptor instead.
+func (*GetBuildRequest) Descriptor() ([]byte, []int) {
+	return file_ra_build_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetBuildRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetBuildRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type StreamBuildLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamBuildLogsRequest) Reset() {
+	*x = StreamBuildLogsRequest{}
+	mi := &file_ra_build_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamBuildLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamBuildLogsRequest) ProtoMessage() {}
+
+func (x *StreamBuildLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_build_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamBuildLogsRequest.ProtoReflect.Descriptor instead.
+func (*StreamBuildLogsRequest) Descriptor() ([]byte, []int) {
+	return file_ra_build_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *StreamBuildLogsRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *StreamBuildLogsRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type BuildResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseResponse      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Data          *Build                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildResponse) Reset() {
+	*x = BuildResponse{}
+	mi := &file_ra_build_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildResponse) ProtoMessage() {}
+
+func (x *BuildResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_build_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildResponse.ProtoReflect.Descriptor instead.
+func (*BuildResponse) Descriptor() ([]byte, []int) {
+	return file_ra_build_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BuildResponse) GetBase() *gen.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *BuildResponse) GetData() *Build {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type BuildLogLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Line          string                 `protobuf:"bytes,1,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BuildLogLine) Reset() {
+	*x = BuildLogLine{}
+	mi := &file_ra_build_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BuildLogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BuildLogLine) ProtoMessage() {}
+
+func (x *BuildLogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_build_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BuildLogLine.ProtoReflect.Descriptor instead.
+func (*BuildLogLine) Descriptor() ([]byte, []int) {
+	return file_ra_build_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BuildLogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+var File_ra_build_proto protoreflect.FileDescriptor
+
+const file_ra_build_proto_rawDesc = "" +
+	"\n" +
+	"\x0era/build.proto\x12\x02ra\x1a\n" +
+	"core.proto\x1a\x0fra/object.proto\"\xb1\x01\n" +
+	"\x11BuildImageRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"base_image\x18\x03 \x01(\tR\tbaseImage\x12\x1f\n" +
+	"\vstatic_ipv4\x18\x04 \x01(\tR\n" +
+	"staticIpv4\x12!\n" +
+	"\fipv4_gateway\x18\x05 \x01(\tR\vipv4Gateway\"H\n" +
+	"\x0fGetBuildRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"O\n" +
+	"\x16StreamBuildLogsRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"V\n" +
+	"\rBuildResponse\x12&\n" +
+	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12\x1d\n" +
+	"\x04data\x18\x02 \x01(\v2\t.ra.BuildR\x04data\"\"\n" +
+	"\fBuildLogLine\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\tR\x04line2\xbd\x01\n" +
+	"\fBuildService\x126\n" +
+	"\n" +
+	"BuildImage\x12\x15.ra.BuildImageRequest\x1a\x11.ra.BuildResponse\x122\n" +
+	"\bGetBuild\x12\x13.ra.GetBuildRequest\x1a\x11.ra.BuildResponse\x12A\n" +
+	"\x0fStreamBuildLogs\x12\x1a.ra.StreamBuildLogsRequest\x1a\x10.ra.BuildLogLine0\x01B\x0eZ\fra/api/protob\x06proto3"
+
+var (
+	file_ra_build_proto_rawDescOnce sync.Once
+	file_ra_build_proto_rawDescData []byte
+)
+
+func file_ra_build_proto_rawDescGZIP() []byte {
+	file_ra_build_proto_rawDescOnce.Do(func() {
+		file_ra_build_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ra_build_proto_rawDesc), len(file_ra_build_proto_rawDesc)))
+	})
+	return file_ra_build_proto_rawDescData
+}
+
+var file_ra_build_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_ra_build_proto_goTypes = []any{
+	(*BuildImageRequest)(nil),      // 0: ra.BuildImageRequest
+	(*GetBuildRequest)(nil),        // 1: ra.GetBuildRequest
+	(*StreamBuildLogsRequest)(nil), // 2: ra.StreamBuildLogsRequest
+	(*BuildResponse)(nil),          // 3: ra.BuildResponse
+	(*BuildLogLine)(nil),           // 4: ra.BuildLogLine
+	(*gen.BaseRequest)(nil),        // 5: core.BaseRequest
+	(*gen.BaseResponse)(nil),       // 6: core.BaseResponse
+	(*Build)(nil),                  // 7: ra.Build
+}
+var file_ra_build_proto_depIdxs = []int32{
+	5, // 0: ra.BuildImageRequest.base:type_name -> core.BaseRequest
+	5, // 1: ra.GetBuildRequest.base:type_name -> core.BaseRequest
+	5, // 2: ra.StreamBuildLogsRequest.base:type_name -> core.BaseRequest
+	6, // 3: ra.BuildResponse.base:type_name -> core.BaseResponse
+	7, // 4: ra.BuildResponse.data:type_name -> ra.Build
+	0, // 5: ra.BuildService.BuildImage:input_type -> ra.BuildImageRequest
+	1, // 6: ra.BuildService.GetBuild:input_type -> ra.GetBuildRequest
+	2, // 7: ra.BuildService.StreamBuildLogs:input_type -> ra.StreamBuildLogsRequest
+	3, // 8: ra.BuildService.BuildImage:output_type -> ra.BuildResponse
+	3, // 9: ra.BuildService.GetBuild:output_type -> ra.BuildResponse
+	4, // 10: ra.BuildService.StreamBuildLogs:output_type -> ra.BuildLogLine
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_ra_build_proto_init() }
+func file_ra_build_proto_init() {
+	if File_ra_build_proto != nil {
+		return
+	}
+	file_ra_object_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ra_build_proto_rawDesc), len(file_ra_build_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ra_build_proto_goTypes,
+		DependencyIndexes: file_ra_build_proto_depIdxs,
+		MessageInfos:      file_ra_build_proto_msgTypes,
+	}.Build()
+	File_ra_build_proto = out.File
+	file_ra_build_proto_goTypes = nil
+	file_ra_build_proto_depIdxs = nil
+}
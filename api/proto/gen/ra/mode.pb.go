@@ -0,0 +1,684 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: ra/mode.proto
+
+package proto
+
+import (
+	gen "github.com/cynxees/cynx-core/proto/gen"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Mode mirrors constant.ModeType; values are append-only so existing clients keep decoding
+type Mode int32
+
+const (
+	Mode_MODE_UNSPECIFIED         Mode = 0
+	Mode_MODE_WORDLE              Mode = 1
+	Mode_MODE_SUDOKU              Mode = 2
+	Mode_MODE_HANGMAN             Mode = 3
+	Mode_MODE_MEMORY              Mode = 4
+	Mode_MODE_QUIZ                Mode = 5
+	Mode_MODE_CROSSWORD           Mode = 6
+	Mode_MODE_PUZZLE              Mode = 7
+	Mode_MODE_TRIVIA              Mode = 8
+	Mode_MODE_FLASHCARDS          Mode = 9
+	Mode_MODE_MATCHING            Mode = 10
+	Mode_MODE_FILL_IN_THE_BLANK   Mode = 11
+	Mode_MODE_MULTIPLE_CHOICE     Mode = 12
+	Mode_MODE_TRUE_FALSE          Mode = 13
+	Mode_MODE_SORTING             Mode = 14
+	Mode_MODE_SEQUENCE            Mode = 15
+	Mode_MODE_WORD_SEARCH         Mode = 16
+	Mode_MODE_ANAGRAM             Mode = 17
+	Mode_MODE_RIDDLES             Mode = 18
+	Mode_MODE_LOGIC_PUZZLE        Mode = 19
+	Mode_MODE_MATH_PUZZLE         Mode = 20
+	Mode_MODE_VISUAL_PUZZLE       Mode = 21
+	Mode_MODE_AUDIO_PUZZLE        Mode = 22
+	Mode_MODE_CODE_PUZZLE         Mode = 23
+	Mode_MODE_ESCAPE_ROOM         Mode = 24
+	Mode_MODE_SCAVENGER_HUNT      Mode = 25
+	Mode_MODE_STORY_PUZZLE        Mode = 26
+	Mode_MODE_WORD_ASSOCIATION    Mode = 27
+	Mode_MODE_NUMBER_PUZZLE       Mode = 28
+	Mode_MODE_PATTERN_RECOGNITION Mode = 29
+	Mode_MODE_TRIVIA_CHALLENGE    Mode = 30
+	Mode_MODE_FLASH_QUIZ          Mode = 31
+	Mode_MODE_INTERACTIVE_STORY   Mode = 32
+	Mode_MODE_CREATIVE_WRITING    Mode = 33
+)
+
+// Enum value maps for Mode.
+var (
+	Mode_name = map[int32]string{
+		0:  "MODE_UNSPECIFIED",
+		1:  "MODE_WORDLE",
+		2:  "MODE_SUDOKU",
+		3:  "MODE_HANGMAN",
+		4:  "MODE_MEMORY",
+		5:  "MODE_QUIZ",
+		6:  "MODE_CROSSWORD",
+		7:  "MODE_PUZZLE",
+		8:  "MODE_TRIVIA",
+		9:  "MODE_FLASHCARDS",
+		10: "MODE_MATCHING",
+		11: "MODE_FILL_IN_THE_BLANK",
+		12: "MODE_MULTIPLE_CHOICE",
+		13: "MODE_TRUE_FALSE",
+		14: "MODE_SORTING",
+		15: "MODE_SEQUENCE",
+		16: "MODE_WORD_SEARCH",
+		17: "MODE_ANAGRAM",
+		18: "MODE_RIDDLES",
+		19: "MODE_LOGIC_PUZZLE",
+		20: "MODE_MATH_PUZZLE",
+		21: "MODE_VISUAL_PUZZLE",
+		22: "MODE_AUDIO_PUZZLE",
+		23: "MODE_CODE_PUZZLE",
+		24: "MODE_ESCAPE_ROOM",
+		25: "MODE_SCAVENGER_HUNT",
+		26: "MODE_STORY_PUZZLE",
+		27: "MODE_WORD_ASSOCIATION",
+		28: "MODE_NUMBER_PUZZLE",
+		29: "MODE_PATTERN_RECOGNITION",
+		30: "MODE_TRIVIA_CHALLENGE",
+		31: "MODE_FLASH_QUIZ",
+		32: "MODE_INTERACTIVE_STORY",
+		33: "MODE_CREATIVE_WRITING",
+	}
+	Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED":         0,
+		"MODE_WORDLE":              1,
+		"MODE_SUDOKU":              2,
+		"MODE_HANGMAN":             3,
+		"MODE_MEMORY":              4,
+		"MODE_QUIZ":                5,
+		"MODE_CROSSWORD":           6,
+		"MODE_PUZZLE":              7,
+		"MODE_TRIVIA":              8,
+		"MODE_FLASHCARDS":          9,
+		"MODE_MATCHING":            10,
+		"MODE_FILL_IN_THE_BLANK":   11,
+		"MODE_MULTIPLE_CHOICE":     12,
+		"MODE_TRUE_FALSE":          13,
+		"MODE_SORTING":             14,
+		"MODE_SEQUENCE":            15,
+		"MODE_WORD_SEARCH":         16,
+		"MODE_ANAGRAM":             17,
+		"MODE_RIDDLES":             18,
+		"MODE_LOGIC_PUZZLE":        19,
+		"MODE_MATH_PUZZLE":         20,
+		"MODE_VISUAL_PUZZLE":       21,
+		"MODE_AUDIO_PUZZLE":        22,
+		"MODE_CODE_PUZZLE":         23,
+		"MODE_ESCAPE_ROOM":         24,
+		"MODE_SCAVENGER_HUNT":      25,
+		"MODE_STORY_PUZZLE":        26,
+		"MODE_WORD_ASSOCIATION":    27,
+		"MODE_NUMBER_PUZZLE":       28,
+		"MODE_PATTERN_RECOGNITION": 29,
+		"MODE_TRIVIA_CHALLENGE":    30,
+		"MODE_FLASH_QUIZ":          31,
+		"MODE_INTERACTIVE_STORY":   32,
+		"MODE_CREATIVE_WRITING":    33,
+	}
+)
+
+func (x Mode) Enum() *Mode {
+	p := new(Mode)
+	*p = x
+	return p
+}
+
+func (x Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_ra_mode_proto_enumTypes[0].Descriptor()
+}
+
+func (Mode) Type() protoreflect.EnumType {
+	return &file_ra_mode_proto_enumTypes[0]
+}
+
+func (x Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Mode.Descriptor instead.
+func (Mode) EnumDescriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{0}
+}
+
+type ModeInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mode          Mode                   `protobuf:"varint,1,opt,name=mode,proto3,enum=ra.Mode" json:"mode,omitempty"`
+	Category      string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModeInfo) Reset() {
+	*x = ModeInfo{}
+	mi := &file_ra_mode_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeInfo) ProtoMessage() {}
+
+func (x *ModeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_mode_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeInfo.ProtoReflect.Descriptor instead.
+func (*ModeInfo) Descriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ModeInfo) GetMode() Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return Mode_MODE_UNSPECIFIED
+}
+
+func (x *ModeInfo) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type ListModesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModesRequest) Reset() {
+	*x = ListModesRequest{}
+	mi := &file_ra_mode_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModesRequest) ProtoMessage() {}
+
+func (x *ListModesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_mode_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModesRequest.ProtoReflect.Descriptor instead.
+func (*ListModesRequest) Descriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListModesRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type GetModeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Mode          Mode                   `protobuf:"varint,2,opt,name=mode,proto3,enum=ra.Mode" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetModeRequest) Reset() {
+	*x = GetModeRequest{}
+	mi := &file_ra_mode_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModeRequest) ProtoMessage() {}
+
+func (x *GetModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_mode_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModeRequest.ProtoReflect.Descriptor instead.
+func (*GetModeRequest) Descriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetModeRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *GetModeRequest) GetMode() Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return Mode_MODE_UNSPECIFIED
+}
+
+// CheckAnswerRequest compares guess to answer with the matcher configured for mode
+type CheckAnswerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Mode          Mode                   `protobuf:"varint,2,opt,name=mode,proto3,enum=ra.Mode" json:"mode,omitempty"`
+	Guess         string                 `protobuf:"bytes,3,opt,name=guess,proto3" json:"guess,omitempty"`
+	Answer        string                 `protobuf:"bytes,4,opt,name=answer,proto3" json:"answer,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAnswerRequest) Reset() {
+	*x = CheckAnswerRequest{}
+	mi := &file_ra_mode_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAnswerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAnswerRequest) ProtoMessage() {}
+
+func (x *CheckAnswerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_mode_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAnswerRequest.ProtoReflect.Descriptor instead.
+func (*CheckAnswerRequest) Descriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckAnswerRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CheckAnswerRequest) GetMode() Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return Mode_MODE_UNSPECIFIED
+}
+
+func (x *CheckAnswerRequest) GetGuess() string {
+	if x != nil {
+		return x.Guess
+	}
+	return ""
+}
+
+func (x *CheckAnswerRequest) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+type ListModesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseResponse      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Data          []*ModeInfo            `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModesResponse) Reset() {
+	*x = ListModesResponse{}
+	mi := &file_ra_mode_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModesResponse) ProtoMessage() {}
+
+func (x *ListModesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_mode_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModesResponse.ProtoReflect.Descriptor instead.
+func (*ListModesResponse) Descriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListModesResponse) GetBase() *gen.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ListModesResponse) GetData() []*ModeInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ModeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseResponse      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Data          *ModeInfo              `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModeResponse) Reset() {
+	*x = ModeResponse{}
+	mi := &file_ra_mode_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModeResponse) ProtoMessage() {}
+
+func (x *ModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_mode_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModeResponse.ProtoReflect.Descriptor instead.
+func (*ModeResponse) Descriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ModeResponse) GetBase() *gen.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ModeResponse) GetData() *ModeInfo {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type CheckAnswerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseResponse      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Correct       bool                   `protobuf:"varint,2,opt,name=correct,proto3" json:"correct,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAnswerResponse) Reset() {
+	*x = CheckAnswerResponse{}
+	mi := &file_ra_mode_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAnswerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAnswerResponse) ProtoMessage() {}
+
+func (x *CheckAnswerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_mode_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAnswerResponse.ProtoReflect.Descriptor instead.
+func (*CheckAnswerResponse) Descriptor() ([]byte, []int) {
+	return file_ra_mode_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CheckAnswerResponse) GetBase() *gen.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *CheckAnswerResponse) GetCorrect() bool {
+	if x != nil {
+		return x.Correct
+	}
+	return false
+}
+
+var File_ra_mode_proto protoreflect.FileDescriptor
+
+const file_ra_mode_proto_rawDesc = "" +
+	"\n" +
+	"\rra/mode.proto\x12\x02ra\x1a\n" +
+	"core.proto\"D\n" +
+	"\bModeInfo\x12\x1c\n" +
+	"\x04mode\x18\x01 \x01(\x0e2\b.ra.ModeR\x04mode\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\"9\n" +
+	"\x10ListModesRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\"U\n" +
+	"\x0eGetModeRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x1c\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\b.ra.ModeR\x04mode\"\x87\x01\n" +
+	"\x12CheckAnswerRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x1c\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\b.ra.ModeR\x04mode\x12\x14\n" +
+	"\x05guess\x18\x03 \x01(\tR\x05guess\x12\x16\n" +
+	"\x06answer\x18\x04 \x01(\tR\x06answer\"]\n" +
+	"\x11ListModesResponse\x12&\n" +
+	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12 \n" +
+	"\x04data\x18\x02 \x03(\v2\f.ra.ModeInfoR\x04data\"X\n" +
+	"\fModeResponse\x12&\n" +
+	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12 \n" +
+	"\x04data\x18\x02 \x01(\v2\f.ra.ModeInfoR\x04data\"W\n" +
+	"\x13CheckAnswerResponse\x12&\n" +
+	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12\x18\n" +
+	"\acorrect\x18\x02 \x01(\bR\acorrect*\xe8\x05\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vMODE_WORDLE\x10\x01\x12\x0f\n" +
+	"\vMODE_SUDOKU\x10\x02\x12\x10\n" +
+	"\fMODE_HANGMAN\x10\x03\x12\x0f\n" +
+	"\vMODE_MEMORY\x10\x04\x12\r\n" +
+	"\tMODE_QUIZ\x10\x05\x12\x12\n" +
+	"\x0eMODE_CROSSWORD\x10\x06\x12\x0f\n" +
+	"\vMODE_PUZZLE\x10\a\x12\x0f\n" +
+	"\vMODE_TRIVIA\x10\b\x12\x13\n" +
+	"\x0fMODE_FLASHCARDS\x10\t\x12\x11\n" +
+	"\rMODE_MATCHING\x10\n" +
+	"\x12\x1a\n" +
+	"\x16MODE_FILL_IN_THE_BLANK\x10\v\x12\x18\n" +
+	"\x14MODE_MULTIPLE_CHOICE\x10\f\x12\x13\n" +
+	"\x0fMODE_TRUE_FALSE\x10\r\x12\x10\n" +
+	"\fMODE_SORTING\x10\x0e\x12\x11\n" +
+	"\rMODE_SEQUENCE\x10\x0f\x12\x14\n" +
+	"\x10MODE_WORD_SEARCH\x10\x10\x12\x10\n" +
+	"\fMODE_ANAGRAM\x10\x11\x12\x10\n" +
+	"\fMODE_RIDDLES\x10\x12\x12\x15\n" +
+	"\x11MODE_LOGIC_PUZZLE\x10\x13\x12\x14\n" +
+	"\x10MODE_MATH_PUZZLE\x10\x14\x12\x16\n" +
+	"\x12MODE_VISUAL_PUZZLE\x10\x15\x12\x15\n" +
+	"\x11MODE_AUDIO_PUZZLE\x10\x16\x12\x14\n" +
+	"\x10MODE_CODE_PUZZLE\x10\x17\x12\x14\n" +
+	"\x10MODE_ESCAPE_ROOM\x10\x18\x12\x17\n" +
+	"\x13MODE_SCAVENGER_HUNT\x10\x19\x12\x15\n" +
+	"\x11MODE_STORY_PUZZLE\x10\x1a\x12\x19\n" +
+	"\x15MODE_WORD_ASSOCIATION\x10\x1b\x12\x16\n" +
+	"\x12MODE_NUMBER_PUZZLE\x10\x1c\x12\x1c\n" +
+	"\x18MODE_PATTERN_RECOGNITION\x10\x1d\x12\x19\n" +
+	"\x15MODE_TRIVIA_CHALLENGE\x10\x1e\x12\x13\n" +
+	"\x0fMODE_FLASH_QUIZ\x10\x1f\x12\x1a\n" +
+	"\x16MODE_INTERACTIVE_STORY\x10 \x12\x19\n" +
+	"\x15MODE_CREATIVE_WRITING\x10!2\xb8\x01\n" +
+	"\vModeService\x128\n" +
+	"\tListModes\x12\x14.ra.ListModesRequest\x1a\x15.ra.ListModesResponse\x12/\n" +
+	"\aGetMode\x12\x12.ra.GetModeRequest\x1a\x10.ra.ModeResponse\x12>\n" +
+	"\vCheckAnswer\x12\x16.ra.CheckAnswerRequest\x1a\x17.ra.CheckAnswerResponseB\x0eZ\fra/api/protob\x06proto3"
+
+var (
+	file_ra_mode_proto_rawDescOnce sync.Once
+	file_ra_mode_proto_rawDescData []byte
+)
+
+func file_ra_mode_proto_rawDescGZIP() []byte {
+	file_ra_mode_proto_rawDescOnce.Do(func() {
+		file_ra_mode_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ra_mode_proto_rawDesc), len(file_ra_mode_proto_rawDesc)))
+	})
+	return file_ra_mode_proto_rawDescData
+}
+
+var file_ra_mode_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ra_mode_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_ra_mode_proto_goTypes = []any{
+	(Mode)(0),                   // 0: ra.Mode
+	(*ModeInfo)(nil),            // 1: ra.ModeInfo
+	(*ListModesRequest)(nil),    // 2: ra.ListModesRequest
+	(*GetModeRequest)(nil),      // 3: ra.GetModeRequest
+	(*CheckAnswerRequest)(nil),  // 4: ra.CheckAnswerRequest
+	(*ListModesResponse)(nil),   // 5: ra.ListModesResponse
+	(*ModeResponse)(nil),        // 6: ra.ModeResponse
+	(*CheckAnswerResponse)(nil), // 7: ra.CheckAnswerResponse
+	(*gen.BaseRequest)(nil),     // 8: core.BaseRequest
+	(*gen.BaseResponse)(nil),    // 9: core.BaseResponse
+}
+var file_ra_mode_proto_depIdxs = []int32{
+	0,  // 0: ra.ModeInfo.mode:type_name -> ra.Mode
+	8,  // 1: ra.ListModesRequest.base:type_name -> core.BaseRequest
+	8,  // 2: ra.GetModeRequest.base:type_name -> core.BaseRequest
+	0,  // 3: ra.GetModeRequest.mode:type_name -> ra.Mode
+	8,  // 4: ra.CheckAnswerRequest.base:type_name -> core.BaseRequest
+	0,  // 5: ra.CheckAnswerRequest.mode:type_name -> ra.Mode
+	9,  // 6: ra.ListModesResponse.base:type_name -> core.BaseResponse
+	1,  // 7: ra.ListModesResponse.data:type_name -> ra.ModeInfo
+	9,  // 8: ra.ModeResponse.base:type_name -> core.BaseResponse
+	1,  // 9: ra.ModeResponse.data:type_name -> ra.ModeInfo
+	9,  // 10: ra.CheckAnswerResponse.base:type_name -> core.BaseResponse
+	2,  // 11: ra.ModeService.ListModes:input_type -> ra.ListModesRequest
+	3,  // 12: ra.ModeService.GetMode:input_type -> ra.GetModeRequest
+	4,  // 13: ra.ModeService.CheckAnswer:input_type -> ra.CheckAnswerRequest
+	5,  // 14: ra.ModeService.ListModes:output_type -> ra.ListModesResponse
+	6,  // 15: ra.ModeService.GetMode:output_type -> ra.ModeResponse
+	7,  // 16: ra.ModeService.CheckAnswer:output_type -> ra.CheckAnswerResponse
+	14, // [14:17] is the sub-list for method output_type
+	11, // [11:14] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_ra_mode_proto_init() }
+func file_ra_mode_proto_init() {
+	if File_ra_mode_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ra_mode_proto_rawDesc), len(file_ra_mode_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ra_mode_proto_goTypes,
+		DependencyIndexes: file_ra_mode_proto_depIdxs,
+		EnumInfos:         file_ra_mode_proto_enumTypes,
+		MessageInfos:      file_ra_mode_proto_msgTypes,
+	}.Build()
+	File_ra_mode_proto = out.File
+	file_ra_mode_proto_goTypes = nil
+	file_ra_mode_proto_depIdxs = nil
+}
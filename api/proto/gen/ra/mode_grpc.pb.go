@@ -0,0 +1,197 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: ra/mode.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ModeService_ListModes_FullMethodName   = "/ra.ModeService/ListModes"
+	ModeService_GetMode_FullMethodName     = "/ra.ModeService/GetMode"
+	ModeService_CheckAnswer_FullMethodName = "/ra.ModeService/CheckAnswer"
+)
+
+// ModeServiceClient is the client API for ModeService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ModeServiceClient interface {
+	ListModes(ctx context.Context, in *ListModesRequest, opts ...grpc.CallOption) (*ListModesResponse, error)
+	GetMode(ctx context.Context, in *GetModeRequest, opts ...grpc.CallOption) (*ModeResponse, error)
+	CheckAnswer(ctx context.Context, in *CheckAnswerRequest, opts ...grpc.CallOption) (*CheckAnswerResponse, error)
+}
+
+type modeServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewModeServiceClient(cc grpc.ClientConnInterface) ModeServiceClient {
+	return &modeServiceClient{cc}
+}
+
+func (c *modeServiceClient) ListModes(ctx context.Context, in *ListModesRequest, opts ...grpc.CallOption) (*ListModesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModesResponse)
+	err := c.cc.Invoke(ctx, ModeService_ListModes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modeServiceClient) GetMode(ctx context.Context, in *GetModeRequest, opts ...grpc.CallOption) (*ModeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModeResponse)
+	err := c.cc.Invoke(ctx, ModeService_GetMode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *modeServiceClient) CheckAnswer(ctx context.Context, in *CheckAnswerRequest, opts ...grpc.CallOption) (*CheckAnswerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckAnswerResponse)
+	err := c.cc.Invoke(ctx, ModeService_CheckAnswer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ModeServiceServer is the server API for ModeService service.
+// All implementations must embed UnimplementedModeServiceServer
+// for forward compatibility.
+type ModeServiceServer interface {
+	ListModes(context.Context, *ListModesRequest) (*ListModesResponse, error)
+	GetMode(context.Context, *GetModeRequest) (*ModeResponse, error)
+	CheckAnswer(context.Context, *CheckAnswerRequest) (*CheckAnswerResponse, error)
+	mustEmbedUnimplementedModeServiceServer()
+}
+
+// UnimplementedModeServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedModeServiceServer struct{}
+
+func (UnimplementedModeServiceServer) ListModes(context.Context, *ListModesRequest) (*ListModesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModes not implemented")
+}
+func (UnimplementedModeServiceServer) GetMode(context.Context, *GetModeRequest) (*ModeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMode not implemented")
+}
+func (UnimplementedModeServiceServer) CheckAnswer(context.Context, *CheckAnswerRequest) (*CheckAnswerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckAnswer not implemented")
+}
+func (UnimplementedModeServiceServer) mustEmbedUnimplementedModeServiceServer() {}
+func (UnimplementedModeServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeModeServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ModeServiceServer will
+// result in compilation errors.
+type UnsafeModeServiceServer interface {
+	mustEmbedUnimplementedModeServiceServer()
+}
+
+func RegisterModeServiceServer(s grpc.ServiceRegistrar, srv ModeServiceServer) {
+	// If the following call pancis, it indicates UnimplementedModeServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ModeService_ServiceDesc, srv)
+}
+
+func _ModeService_ListModes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModeServiceServer).ListModes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModeService_ListModes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModeServiceServer).ListModes(ctx, req.(*ListModesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModeService_GetMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModeServiceServer).GetMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModeService_GetMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModeServiceServer).GetMode(ctx, req.(*GetModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ModeService_CheckAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ModeServiceServer).CheckAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ModeService_CheckAnswer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ModeServiceServer).CheckAnswer(ctx, req.(*CheckAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ModeService_ServiceDesc is the grpc.ServiceDesc for ModeService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ModeService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ra.ModeService",
+	HandlerType: (*ModeServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListModes",
+			Handler:    _ModeService_ListModes_Handler,
+		},
+		{
+			MethodName: "GetMode",
+			Handler:    _ModeService_GetMode_Handler,
+		},
+		{
+			MethodName: "CheckAnswer",
+			Handler:    _ModeService_CheckAnswer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ra/mode.proto",
+}
@@ -74,6 +74,482 @@ func (x *GetVirtualMachineRequest) GetId() int32 {
 	return 0
 }
 
+type ListVirtualMachinesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	UserId        *int32                 `protobuf:"varint,2,opt,name=user_id,json=userId,proto3,oneof" json:"user_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	SortBy        string                 `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	SortDesc      bool                   `protobuf:"varint,5,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	Page          int32                  `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NameContains  string                 `protobuf:"bytes,8,opt,name=name_contains,json=nameContains,proto3" json:"name_contains,omitempty"`
+	LabelSelector map[string]string      `protobuf:"bytes,9,rep,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVirtualMachinesRequest) Reset() {
+	*x = ListVirtualMachinesRequest{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVirtualMachinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVirtualMachinesRequest) ProtoMessage() {}
+
+func (x *ListVirtualMachinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVirtualMachinesRequest.ProtoReflect.Descriptor instead.
+func (*ListVirtualMachinesRequest) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListVirtualMachinesRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ListVirtualMachinesRequest) GetUserId() int32 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *ListVirtualMachinesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListVirtualMachinesRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListVirtualMachinesRequest) GetSortDesc() bool {
+	if x != nil {
+		return x.SortDesc
+	}
+	return false
+}
+
+func (x *ListVirtualMachinesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListVirtualMachinesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListVirtualMachinesRequest) GetNameContains() string {
+	if x != nil {
+		return x.NameContains
+	}
+	return ""
+}
+
+func (x *ListVirtualMachinesRequest) GetLabelSelector() map[string]string {
+	if x != nil {
+		return x.LabelSelector
+	}
+	return nil
+}
+
+type RenameVirtualMachineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	NewName       string                 `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameVirtualMachineRequest) Reset() {
+	*x = RenameVirtualMachineRequest{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameVirtualMachineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameVirtualMachineRequest) ProtoMessage() {}
+
+func (x *RenameVirtualMachineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameVirtualMachineRequest.ProtoReflect.Descriptor instead.
+func (*RenameVirtualMachineRequest) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RenameVirtualMachineRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *RenameVirtualMachineRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RenameVirtualMachineRequest) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+type SetVirtualMachineLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetVirtualMachineLabelRequest) Reset() {
+	*x = SetVirtualMachineLabelRequest{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetVirtualMachineLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetVirtualMachineLabelRequest) ProtoMessage() {}
+
+func (x *SetVirtualMachineLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetVirtualMachineLabelRequest.ProtoReflect.Descriptor instead.
+func (*SetVirtualMachineLabelRequest) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SetVirtualMachineLabelRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *SetVirtualMachineLabelRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetVirtualMachineLabelRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetVirtualMachineLabelRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type RemoveVirtualMachineLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveVirtualMachineLabelRequest) Reset() {
+	*x = RemoveVirtualMachineLabelRequest{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveVirtualMachineLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveVirtualMachineLabelRequest) ProtoMessage() {}
+
+func (x *RemoveVirtualMachineLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveVirtualMachineLabelRequest.ProtoReflect.Descriptor instead.
+func (*RemoveVirtualMachineLabelRequest) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RemoveVirtualMachineLabelRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *RemoveVirtualMachineLabelRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RemoveVirtualMachineLabelRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type DeleteVirtualMachineRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            int32                  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Force         bool                   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteVirtualMachineRequest) Reset() {
+	*x = DeleteVirtualMachineRequest{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteVirtualMachineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteVirtualMachineRequest) ProtoMessage() {}
+
+func (x *DeleteVirtualMachineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteVirtualMachineRequest.ProtoReflect.Descriptor instead.
+func (*DeleteVirtualMachineRequest) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteVirtualMachineRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *DeleteVirtualMachineRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteVirtualMachineRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type ReconcileVirtualMachinesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseRequest       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileVirtualMachinesRequest) Reset() {
+	*x = ReconcileVirtualMachinesRequest{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileVirtualMachinesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileVirtualMachinesRequest) ProtoMessage() {}
+
+func (x *ReconcileVirtualMachinesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileVirtualMachinesRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileVirtualMachinesRequest) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReconcileVirtualMachinesRequest) GetBase() *gen.BaseRequest {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+type VirtualMachineCorrection struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	FromStatus     string                 `protobuf:"bytes,3,opt,name=from_status,json=fromStatus,proto3" json:"from_status,omitempty"`
+	ToStatus       string                 `protobuf:"bytes,4,opt,name=to_status,json=toStatus,proto3" json:"to_status,omitempty"`
+	ContainerState string                 `protobuf:"bytes,5,opt,name=container_state,json=containerState,proto3" json:"container_state,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VirtualMachineCorrection) Reset() {
+	*x = VirtualMachineCorrection{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VirtualMachineCorrection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VirtualMachineCorrection) ProtoMessage() {}
+
+func (x *VirtualMachineCorrection) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VirtualMachineCorrection.ProtoReflect.Descriptor instead.
+func (*VirtualMachineCorrection) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VirtualMachineCorrection) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *VirtualMachineCorrection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VirtualMachineCorrection) GetFromStatus() string {
+	if x != nil {
+		return x.FromStatus
+	}
+	return ""
+}
+
+func (x *VirtualMachineCorrection) GetToStatus() string {
+	if x != nil {
+		return x.ToStatus
+	}
+	return ""
+}
+
+func (x *VirtualMachineCorrection) GetContainerState() string {
+	if x != nil {
+		return x.ContainerState
+	}
+	return ""
+}
+
 type VirtualMachineResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Base          *gen.BaseResponse      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
@@ -84,7 +560,7 @@ type VirtualMachineResponse struct {
 
 func (x *VirtualMachineResponse) Reset() {
 	*x = VirtualMachineResponse{}
-	mi := &file_ra_virtualmachine_proto_msgTypes[1]
+	mi := &file_ra_virtualmachine_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -96,7 +572,7 @@ func (x *VirtualMachineResponse) String() string {
 func (*VirtualMachineResponse) ProtoMessage() {}
 
 func (x *VirtualMachineResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ra_virtualmachine_proto_msgTypes[1]
+	mi := &file_ra_virtualmachine_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -109,7 +585,7 @@ func (x *VirtualMachineResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VirtualMachineResponse.ProtoReflect.Descriptor instead.
 func (*VirtualMachineResponse) Descriptor() ([]byte, []int) {
-	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{1}
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *VirtualMachineResponse) GetBase() *gen.BaseResponse {
@@ -126,6 +602,162 @@ func (x *VirtualMachineResponse) GetData() *VirtualMachine {
 	return nil
 }
 
+type ListVirtualMachinesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseResponse      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Data          []*VirtualMachine      `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVirtualMachinesResponse) Reset() {
+	*x = ListVirtualMachinesResponse{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVirtualMachinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVirtualMachinesResponse) ProtoMessage() {}
+
+func (x *ListVirtualMachinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVirtualMachinesResponse.ProtoReflect.Descriptor instead.
+func (*ListVirtualMachinesResponse) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListVirtualMachinesResponse) GetBase() *gen.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ListVirtualMachinesResponse) GetData() []*VirtualMachine {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type VirtualMachineLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *gen.BaseResponse      `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,2,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VirtualMachineLabelsResponse) Reset() {
+	*x = VirtualMachineLabelsResponse{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VirtualMachineLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VirtualMachineLabelsResponse) ProtoMessage() {}
+
+func (x *VirtualMachineLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VirtualMachineLabelsResponse.ProtoReflect.Descriptor instead.
+func (*VirtualMachineLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VirtualMachineLabelsResponse) GetBase() *gen.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *VirtualMachineLabelsResponse) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type ReconcileVirtualMachinesResponse struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Base          *gen.BaseResponse           `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Corrections   []*VirtualMachineCorrection `protobuf:"bytes,2,rep,name=corrections,proto3" json:"corrections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileVirtualMachinesResponse) Reset() {
+	*x = ReconcileVirtualMachinesResponse{}
+	mi := &file_ra_virtualmachine_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileVirtualMachinesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileVirtualMachinesResponse) ProtoMessage() {}
+
+func (x *ReconcileVirtualMachinesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ra_virtualmachine_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileVirtualMachinesResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileVirtualMachinesResponse) Descriptor() ([]byte, []int) {
+	return file_ra_virtualmachine_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ReconcileVirtualMachinesResponse) GetBase() *gen.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ReconcileVirtualMachinesResponse) GetCorrections() []*VirtualMachineCorrection {
+	if x != nil {
+		return x.Corrections
+	}
+	return nil
+}
+
 var File_ra_virtualmachine_proto protoreflect.FileDescriptor
 
 const file_ra_virtualmachine_proto_rawDesc = "" +
@@ -134,12 +766,71 @@ const file_ra_virtualmachine_proto_rawDesc = "" +
 	"core.proto\x1a\x0fra/object.proto\"Q\n" +
 	"\x18GetVirtualMachineRequest\x12%\n" +
 	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\x05R\x02id\"h\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\"\xad\x03\n" +
+	"\x1aListVirtualMachinesRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x1c\n" +
+	"\auser_id\x18\x02 \x01(\x05H\x00R\x06userId\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x17\n" +
+	"\asort_by\x18\x04 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\x05 \x01(\bR\bsortDesc\x12\x12\n" +
+	"\x04page\x18\x06 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12#\n" +
+	"\rname_contains\x18\b \x01(\tR\fnameContains\x12X\n" +
+	"\x0elabel_selector\x18\t \x03(\v21.ra.ListVirtualMachinesRequest.LabelSelectorEntryR\rlabelSelector\x1a@\n" +
+	"\x12LabelSelectorEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\n" +
+	"\n" +
+	"\b_user_id\"o\n" +
+	"\x1bRenameVirtualMachineRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x19\n" +
+	"\bnew_name\x18\x03 \x01(\tR\anewName\"~\n" +
+	"\x1dSetVirtualMachineLabelRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"k\n" +
+	" RemoveVirtualMachineLabelRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\"j\n" +
+	"\x1bDeleteVirtualMachineRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\x05R\x02id\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"H\n" +
+	"\x1fReconcileVirtualMachinesRequest\x12%\n" +
+	"\x04base\x18\x01 \x01(\v2\x11.core.BaseRequestR\x04base\"\xa5\x01\n" +
+	"\x18VirtualMachineCorrection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
+	"\vfrom_status\x18\x03 \x01(\tR\n" +
+	"fromStatus\x12\x1b\n" +
+	"\tto_status\x18\x04 \x01(\tR\btoStatus\x12'\n" +
+	"\x0fcontainer_state\x18\x05 \x01(\tR\x0econtainerState\"h\n" +
 	"\x16VirtualMachineResponse\x12&\n" +
 	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12&\n" +
-	"\x04data\x18\x02 \x01(\v2\x12.ra.VirtualMachineR\x04data2f\n" +
+	"\x04data\x18\x02 \x01(\v2\x12.ra.VirtualMachineR\x04data\"m\n" +
+	"\x1bListVirtualMachinesResponse\x12&\n" +
+	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12&\n" +
+	"\x04data\x18\x02 \x03(\v2\x12.ra.VirtualMachineR\x04data\"\xc7\x01\n" +
+	"\x1cVirtualMachineLabelsResponse\x12&\n" +
+	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12D\n" +
+	"\x06labels\x18\x02 \x03(\v2,.ra.VirtualMachineLabelsResponse.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x8a\x01\n" +
+	" ReconcileVirtualMachinesResponse\x12&\n" +
+	"\x04base\x18\x01 \x01(\v2\x12.core.BaseResponseR\x04base\x12>\n" +
+	"\vcorrections\x18\x02 \x03(\v2\x1c.ra.VirtualMachineCorrectionR\vcorrections2\x93\x05\n" +
 	"\x15VirtualMachineService\x12M\n" +
-	"\x11GetVirtualMachine\x12\x1c.ra.GetVirtualMachineRequest\x1a\x1a.ra.VirtualMachineResponseB\x0eZ\fra/api/protob\x06proto3"
+	"\x11GetVirtualMachine\x12\x1c.ra.GetVirtualMachineRequest\x1a\x1a.ra.VirtualMachineResponse\x12V\n" +
+	"\x13ListVirtualMachines\x12\x1e.ra.ListVirtualMachinesRequest\x1a\x1f.ra.ListVirtualMachinesResponse\x12S\n" +
+	"\x14RenameVirtualMachine\x12\x1f.ra.RenameVirtualMachineRequest\x1a\x1a.ra.VirtualMachineResponse\x12]\n" +
+	"\x16SetVirtualMachineLabel\x12!.ra.SetVirtualMachineLabelRequest\x1a .ra.VirtualMachineLabelsResponse\x12c\n" +
+	"\x19RemoveVirtualMachineLabel\x12$.ra.RemoveVirtualMachineLabelRequest\x1a .ra.VirtualMachineLabelsResponse\x12S\n" +
+	"\x14DeleteVirtualMachine\x12\x1f.ra.DeleteVirtualMachineRequest\x1a\x1a.ra.VirtualMachineResponse\x12e\n" +
+	"\x18ReconcileVirtualMachines\x12#.ra.ReconcileVirtualMachinesRequest\x1a$.ra.ReconcileVirtualMachinesResponseB\x0eZ\fra/api/protob\x06proto3"
 
 var (
 	file_ra_virtualmachine_proto_rawDescOnce sync.Once
@@ -153,25 +844,62 @@ func file_ra_virtualmachine_proto_rawDescGZIP() []byte {
 	return file_ra_virtualmachine_proto_rawDescData
 }
 
-var file_ra_virtualmachine_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_ra_virtualmachine_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_ra_virtualmachine_proto_goTypes = []any{
-	(*GetVirtualMachineRequest)(nil), // 0: ra.GetVirtualMachineRequest
-	(*VirtualMachineResponse)(nil),   // 1: ra.VirtualMachineResponse
-	(*gen.BaseRequest)(nil),          // 2: core.BaseRequest
-	(*gen.BaseResponse)(nil),         // 3: core.BaseResponse
-	(*VirtualMachine)(nil),           // 4: ra.VirtualMachine
+	(*GetVirtualMachineRequest)(nil),         // 0: ra.GetVirtualMachineRequest
+	(*ListVirtualMachinesRequest)(nil),       // 1: ra.ListVirtualMachinesRequest
+	(*RenameVirtualMachineRequest)(nil),      // 2: ra.RenameVirtualMachineRequest
+	(*SetVirtualMachineLabelRequest)(nil),    // 3: ra.SetVirtualMachineLabelRequest
+	(*RemoveVirtualMachineLabelRequest)(nil), // 4: ra.RemoveVirtualMachineLabelRequest
+	(*DeleteVirtualMachineRequest)(nil),      // 5: ra.DeleteVirtualMachineRequest
+	(*ReconcileVirtualMachinesRequest)(nil),  // 6: ra.ReconcileVirtualMachinesRequest
+	(*VirtualMachineCorrection)(nil),         // 7: ra.VirtualMachineCorrection
+	(*VirtualMachineResponse)(nil),           // 8: ra.VirtualMachineResponse
+	(*ListVirtualMachinesResponse)(nil),      // 9: ra.ListVirtualMachinesResponse
+	(*VirtualMachineLabelsResponse)(nil),     // 10: ra.VirtualMachineLabelsResponse
+	(*ReconcileVirtualMachinesResponse)(nil), // 11: ra.ReconcileVirtualMachinesResponse
+	nil,                                      // 12: ra.ListVirtualMachinesRequest.LabelSelectorEntry
+	nil,                                      // 13: ra.VirtualMachineLabelsResponse.LabelsEntry
+	(*gen.BaseRequest)(nil),                  // 14: core.BaseRequest
+	(*gen.BaseResponse)(nil),                 // 15: core.BaseResponse
+	(*VirtualMachine)(nil),                   // 16: ra.VirtualMachine
 }
 var file_ra_virtualmachine_proto_depIdxs = []int32{
-	2, // 0: ra.GetVirtualMachineRequest.base:type_name -> core.BaseRequest
-	3, // 1: ra.VirtualMachineResponse.base:type_name -> core.BaseResponse
-	4, // 2: ra.VirtualMachineResponse.data:type_name -> ra.VirtualMachine
-	0, // 3: ra.VirtualMachineService.GetVirtualMachine:input_type -> ra.GetVirtualMachineRequest
-	1, // 4: ra.VirtualMachineService.GetVirtualMachine:output_type -> ra.VirtualMachineResponse
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	14, // 0: ra.GetVirtualMachineRequest.base:type_name -> core.BaseRequest
+	14, // 1: ra.ListVirtualMachinesRequest.base:type_name -> core.BaseRequest
+	12, // 2: ra.ListVirtualMachinesRequest.label_selector:type_name -> ra.ListVirtualMachinesRequest.LabelSelectorEntry
+	14, // 3: ra.RenameVirtualMachineRequest.base:type_name -> core.BaseRequest
+	14, // 4: ra.SetVirtualMachineLabelRequest.base:type_name -> core.BaseRequest
+	14, // 5: ra.RemoveVirtualMachineLabelRequest.base:type_name -> core.BaseRequest
+	14, // 6: ra.DeleteVirtualMachineRequest.base:type_name -> core.BaseRequest
+	14, // 7: ra.ReconcileVirtualMachinesRequest.base:type_name -> core.BaseRequest
+	15, // 8: ra.VirtualMachineResponse.base:type_name -> core.BaseResponse
+	16, // 9: ra.VirtualMachineResponse.data:type_name -> ra.VirtualMachine
+	15, // 10: ra.ListVirtualMachinesResponse.base:type_name -> core.BaseResponse
+	16, // 11: ra.ListVirtualMachinesResponse.data:type_name -> ra.VirtualMachine
+	15, // 12: ra.VirtualMachineLabelsResponse.base:type_name -> core.BaseResponse
+	13, // 13: ra.VirtualMachineLabelsResponse.labels:type_name -> ra.VirtualMachineLabelsResponse.LabelsEntry
+	15, // 14: ra.ReconcileVirtualMachinesResponse.base:type_name -> core.BaseResponse
+	7,  // 15: ra.ReconcileVirtualMachinesResponse.corrections:type_name -> ra.VirtualMachineCorrection
+	0,  // 16: ra.VirtualMachineService.GetVirtualMachine:input_type -> ra.GetVirtualMachineRequest
+	1,  // 17: ra.VirtualMachineService.ListVirtualMachines:input_type -> ra.ListVirtualMachinesRequest
+	2,  // 18: ra.VirtualMachineService.RenameVirtualMachine:input_type -> ra.RenameVirtualMachineRequest
+	3,  // 19: ra.VirtualMachineService.SetVirtualMachineLabel:input_type -> ra.SetVirtualMachineLabelRequest
+	4,  // 20: ra.VirtualMachineService.RemoveVirtualMachineLabel:input_type -> ra.RemoveVirtualMachineLabelRequest
+	5,  // 21: ra.VirtualMachineService.DeleteVirtualMachine:input_type -> ra.DeleteVirtualMachineRequest
+	6,  // 22: ra.VirtualMachineService.ReconcileVirtualMachines:input_type -> ra.ReconcileVirtualMachinesRequest
+	8,  // 23: ra.VirtualMachineService.GetVirtualMachine:output_type -> ra.VirtualMachineResponse
+	9,  // 24: ra.VirtualMachineService.ListVirtualMachines:output_type -> ra.ListVirtualMachinesResponse
+	8,  // 25: ra.VirtualMachineService.RenameVirtualMachine:output_type -> ra.VirtualMachineResponse
+	10, // 26: ra.VirtualMachineService.SetVirtualMachineLabel:output_type -> ra.VirtualMachineLabelsResponse
+	10, // 27: ra.VirtualMachineService.RemoveVirtualMachineLabel:output_type -> ra.VirtualMachineLabelsResponse
+	8,  // 28: ra.VirtualMachineService.DeleteVirtualMachine:output_type -> ra.VirtualMachineResponse
+	11, // 29: ra.VirtualMachineService.ReconcileVirtualMachines:output_type -> ra.ReconcileVirtualMachinesResponse
+	23, // [23:30] is the sub-list for method output_type
+	16, // [16:23] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_ra_virtualmachine_proto_init() }
@@ -180,13 +908,14 @@ func file_ra_virtualmachine_proto_init() {
 		return
 	}
 	file_ra_object_proto_init()
+	file_ra_virtualmachine_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ra_virtualmachine_proto_rawDesc), len(file_ra_virtualmachine_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
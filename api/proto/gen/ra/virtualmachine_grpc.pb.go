@@ -19,7 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	VirtualMachineService_GetVirtualMachine_FullMethodName = "/ra.VirtualMachineService/GetVirtualMachine"
+	VirtualMachineService_GetVirtualMachine_FullMethodName         = "/ra.VirtualMachineService/GetVirtualMachine"
+	VirtualMachineService_ListVirtualMachines_FullMethodName       = "/ra.VirtualMachineService/ListVirtualMachines"
+	VirtualMachineService_RenameVirtualMachine_FullMethodName      = "/ra.VirtualMachineService/RenameVirtualMachine"
+	VirtualMachineService_SetVirtualMachineLabel_FullMethodName    = "/ra.VirtualMachineService/SetVirtualMachineLabel"
+	VirtualMachineService_RemoveVirtualMachineLabel_FullMethodName = "/ra.VirtualMachineService/RemoveVirtualMachineLabel"
+	VirtualMachineService_DeleteVirtualMachine_FullMethodName      = "/ra.VirtualMachineService/DeleteVirtualMachine"
+	VirtualMachineService_ReconcileVirtualMachines_FullMethodName  = "/ra.VirtualMachineService/ReconcileVirtualMachines"
 )
 
 // VirtualMachineServiceClient is the client API for VirtualMachineService service.
@@ -27,6 +33,12 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type VirtualMachineServiceClient interface {
 	GetVirtualMachine(ctx context.Context, in *GetVirtualMachineRequest, opts ...grpc.CallOption) (*VirtualMachineResponse, error)
+	ListVirtualMachines(ctx context.Context, in *ListVirtualMachinesRequest, opts ...grpc.CallOption) (*ListVirtualMachinesResponse, error)
+	RenameVirtualMachine(ctx context.Context, in *RenameVirtualMachineRequest, opts ...grpc.CallOption) (*VirtualMachineResponse, error)
+	SetVirtualMachineLabel(ctx context.Context, in *SetVirtualMachineLabelRequest, opts ...grpc.CallOption) (*VirtualMachineLabelsResponse, error)
+	RemoveVirtualMachineLabel(ctx context.Context, in *RemoveVirtualMachineLabelRequest, opts ...grpc.CallOption) (*VirtualMachineLabelsResponse, error)
+	DeleteVirtualMachine(ctx context.Context, in *DeleteVirtualMachineRequest, opts ...grpc.CallOption) (*VirtualMachineResponse, error)
+	ReconcileVirtualMachines(ctx context.Context, in *ReconcileVirtualMachinesRequest, opts ...grpc.CallOption) (*ReconcileVirtualMachinesResponse, error)
 }
 
 type virtualMachineServiceClient struct {
@@ -47,11 +59,77 @@ func (c *virtualMachineServiceClient) GetVirtualMachine(ctx context.Context, in
 	return out, nil
 }
 
+func (c *virtualMachineServiceClient) ListVirtualMachines(ctx context.Context, in *ListVirtualMachinesRequest, opts ...grpc.CallOption) (*ListVirtualMachinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVirtualMachinesResponse)
+	err := c.cc.Invoke(ctx, VirtualMachineService_ListVirtualMachines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *virtualMachineServiceClient) RenameVirtualMachine(ctx context.Context, in *RenameVirtualMachineRequest, opts ...grpc.CallOption) (*VirtualMachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VirtualMachineResponse)
+	err := c.cc.Invoke(ctx, VirtualMachineService_RenameVirtualMachine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *virtualMachineServiceClient) SetVirtualMachineLabel(ctx context.Context, in *SetVirtualMachineLabelRequest, opts ...grpc.CallOption) (*VirtualMachineLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VirtualMachineLabelsResponse)
+	err := c.cc.Invoke(ctx, VirtualMachineService_SetVirtualMachineLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *virtualMachineServiceClient) RemoveVirtualMachineLabel(ctx context.Context, in *RemoveVirtualMachineLabelRequest, opts ...grpc.CallOption) (*VirtualMachineLabelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VirtualMachineLabelsResponse)
+	err := c.cc.Invoke(ctx, VirtualMachineService_RemoveVirtualMachineLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *virtualMachineServiceClient) DeleteVirtualMachine(ctx context.Context, in *DeleteVirtualMachineRequest, opts ...grpc.CallOption) (*VirtualMachineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VirtualMachineResponse)
+	err := c.cc.Invoke(ctx, VirtualMachineService_DeleteVirtualMachine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *virtualMachineServiceClient) ReconcileVirtualMachines(ctx context.Context, in *ReconcileVirtualMachinesRequest, opts ...grpc.CallOption) (*ReconcileVirtualMachinesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileVirtualMachinesResponse)
+	err := c.cc.Invoke(ctx, VirtualMachineService_ReconcileVirtualMachines_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VirtualMachineServiceServer is the server API for VirtualMachineService service.
 // All implementations must embed UnimplementedVirtualMachineServiceServer
 // for forward compatibility.
 type VirtualMachineServiceServer interface {
 	GetVirtualMachine(context.Context, *GetVirtualMachineRequest) (*VirtualMachineResponse, error)
+	ListVirtualMachines(context.Context, *ListVirtualMachinesRequest) (*ListVirtualMachinesResponse, error)
+	RenameVirtualMachine(context.Context, *RenameVirtualMachineRequest) (*VirtualMachineResponse, error)
+	SetVirtualMachineLabel(context.Context, *SetVirtualMachineLabelRequest) (*VirtualMachineLabelsResponse, error)
+	RemoveVirtualMachineLabel(context.Context, *RemoveVirtualMachineLabelRequest) (*VirtualMachineLabelsResponse, error)
+	DeleteVirtualMachine(context.Context, *DeleteVirtualMachineRequest) (*VirtualMachineResponse, error)
+	ReconcileVirtualMachines(context.Context, *ReconcileVirtualMachinesRequest) (*ReconcileVirtualMachinesResponse, error)
 	mustEmbedUnimplementedVirtualMachineServiceServer()
 }
 
@@ -65,6 +143,24 @@ type UnimplementedVirtualMachineServiceServer struct{}
 func (UnimplementedVirtualMachineServiceServer) GetVirtualMachine(context.Context, *GetVirtualMachineRequest) (*VirtualMachineResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetVirtualMachine not implemented")
 }
+func (UnimplementedVirtualMachineServiceServer) ListVirtualMachines(context.Context, *ListVirtualMachinesRequest) (*ListVirtualMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVirtualMachines not implemented")
+}
+func (UnimplementedVirtualMachineServiceServer) RenameVirtualMachine(context.Context, *RenameVirtualMachineRequest) (*VirtualMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameVirtualMachine not implemented")
+}
+func (UnimplementedVirtualMachineServiceServer) SetVirtualMachineLabel(context.Context, *SetVirtualMachineLabelRequest) (*VirtualMachineLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVirtualMachineLabel not implemented")
+}
+func (UnimplementedVirtualMachineServiceServer) RemoveVirtualMachineLabel(context.Context, *RemoveVirtualMachineLabelRequest) (*VirtualMachineLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveVirtualMachineLabel not implemented")
+}
+func (UnimplementedVirtualMachineServiceServer) DeleteVirtualMachine(context.Context, *DeleteVirtualMachineRequest) (*VirtualMachineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVirtualMachine not implemented")
+}
+func (UnimplementedVirtualMachineServiceServer) ReconcileVirtualMachines(context.Context, *ReconcileVirtualMachinesRequest) (*ReconcileVirtualMachinesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileVirtualMachines not implemented")
+}
 func (UnimplementedVirtualMachineServiceServer) mustEmbedUnimplementedVirtualMachineServiceServer() {}
 func (UnimplementedVirtualMachineServiceServer) testEmbeddedByValue()                               {}
 
@@ -104,6 +200,114 @@ func _VirtualMachineService_GetVirtualMachine_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VirtualMachineService_ListVirtualMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVirtualMachinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VirtualMachineServiceServer).ListVirtualMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VirtualMachineService_ListVirtualMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VirtualMachineServiceServer).ListVirtualMachines(ctx, req.(*ListVirtualMachinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VirtualMachineService_RenameVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameVirtualMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VirtualMachineServiceServer).RenameVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VirtualMachineService_RenameVirtualMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VirtualMachineServiceServer).RenameVirtualMachine(ctx, req.(*RenameVirtualMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VirtualMachineService_SetVirtualMachineLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetVirtualMachineLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VirtualMachineServiceServer).SetVirtualMachineLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VirtualMachineService_SetVirtualMachineLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VirtualMachineServiceServer).SetVirtualMachineLabel(ctx, req.(*SetVirtualMachineLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VirtualMachineService_RemoveVirtualMachineLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveVirtualMachineLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VirtualMachineServiceServer).RemoveVirtualMachineLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VirtualMachineService_RemoveVirtualMachineLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VirtualMachineServiceServer).RemoveVirtualMachineLabel(ctx, req.(*RemoveVirtualMachineLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VirtualMachineService_DeleteVirtualMachine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteVirtualMachineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VirtualMachineServiceServer).DeleteVirtualMachine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VirtualMachineService_DeleteVirtualMachine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VirtualMachineServiceServer).DeleteVirtualMachine(ctx, req.(*DeleteVirtualMachineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VirtualMachineService_ReconcileVirtualMachines_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileVirtualMachinesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VirtualMachineServiceServer).ReconcileVirtualMachines(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VirtualMachineService_ReconcileVirtualMachines_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VirtualMachineServiceServer).ReconcileVirtualMachines(ctx, req.(*ReconcileVirtualMachinesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VirtualMachineService_ServiceDesc is the grpc.ServiceDesc for VirtualMachineService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -115,6 +319,30 @@ var VirtualMachineService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetVirtualMachine",
 			Handler:    _VirtualMachineService_GetVirtualMachine_Handler,
 		},
+		{
+			MethodName: "ListVirtualMachines",
+			Handler:    _VirtualMachineService_ListVirtualMachines_Handler,
+		},
+		{
+			MethodName: "RenameVirtualMachine",
+			Handler:    _VirtualMachineService_RenameVirtualMachine_Handler,
+		},
+		{
+			MethodName: "SetVirtualMachineLabel",
+			Handler:    _VirtualMachineService_SetVirtualMachineLabel_Handler,
+		},
+		{
+			MethodName: "RemoveVirtualMachineLabel",
+			Handler:    _VirtualMachineService_RemoveVirtualMachineLabel_Handler,
+		},
+		{
+			MethodName: "DeleteVirtualMachine",
+			Handler:    _VirtualMachineService_DeleteVirtualMachine_Handler,
+		},
+		{
+			MethodName: "ReconcileVirtualMachines",
+			Handler:    _VirtualMachineService_ReconcileVirtualMachines_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ra/virtualmachine.proto",
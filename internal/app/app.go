@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/cynxees/cynx-core/src/logger"
 	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/model/entity"
 	"log"
 )
 
@@ -18,7 +19,20 @@ func NewApp(ctx context.Context) (*App, error) {
 	log.Println("Initializing Dependencies")
 	dependencies := NewDependencies(ctx)
 
-	if config.Config.Database.AutoMigrate {
+	if config.Get().Database.DryRunMigrate {
+		pending, err := dependencies.DatabaseClient.DryRunMigrate()
+		if err != nil {
+			logger.Fatal(ctx, "Failed to dry run migrations: ", err)
+		}
+		if len(pending) == 0 {
+			logger.Info(ctx, "Database schema is up to date")
+		}
+		for _, statement := range pending {
+			logger.Warn(ctx, "Pending migration: ", statement)
+		}
+	}
+
+	if config.Get().Database.AutoMigrate {
 		logger.Info(ctx, "Running database migrations")
 		err := dependencies.DatabaseClient.RunMigrations()
 		if err != nil {
@@ -26,11 +40,15 @@ func NewApp(ctx context.Context) (*App, error) {
 		}
 	}
 
+	entity.SetVMResourceBounds(config.Get().VirtualMachine.ResourceBounds())
+
 	logger.Info(ctx, "Initializing Repositories")
 	repos := NewRepos(dependencies)
 
 	logger.Info(ctx, "Initializing Services")
-	services := NewServices(repos)
+	services := NewServices(dependencies, repos)
+
+	go watchConfigReload(ctx)
 
 	logger.Info(ctx, "App initialized")
 	return &App{
@@ -17,17 +17,9 @@ func NewDependencies(ctx context.Context) *Dependencies {
 
 	log.Println("Loading Config")
 	config.InitConfig()
-	logLevel, err := logrus.ParseLevel(config.Config.Elastic.Level)
-	if err != nil {
-		logLevel = logrus.DebugLevel
-	}
 
 	log.Println("Initializing Logger")
-	logger.Init(logger.LoggerConfig{
-		Level:            logLevel,
-		ElasticsearchURL: []string{config.Config.Elastic.Url},
-		ServiceName:      "ra-server",
-	})
+	initLogger()
 
 	logger.Info(ctx, "Connecting to Database")
 	databaseClient, err := dependencies.NewDatabaseClient()
@@ -40,3 +32,19 @@ func NewDependencies(ctx context.Context) *Dependencies {
 		DatabaseClient: databaseClient,
 	}
 }
+
+// initLogger (re)initializes the logger from the current elastic config,
+// falling back to debug level when the level does not parse
+func initLogger() {
+	elastic := config.Get().Elastic
+	logLevel, err := logrus.ParseLevel(elastic.Level)
+	if err != nil {
+		logLevel = logrus.DebugLevel
+	}
+
+	logger.Init(logger.LoggerConfig{
+		Level:            logLevel,
+		ElasticsearchURL: []string{elastic.Url},
+		ServiceName:      "ra-server",
+	})
+}
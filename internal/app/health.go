@@ -0,0 +1,33 @@
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/service/healthservice"
+)
+
+// newHealthServer serves the aggregated health report on /healthz, answering
+// 503 when any component is unhealthy
+func newHealthServer(healthService *healthservice.Service) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		report := healthService.Check(r.Context())
+
+		w.Header().Set("Content-Type", "application/json")
+		if !report.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(report)
+	})
+
+	cfg := config.Get()
+	return &http.Server{
+		Addr:              cfg.App.Address + ":" + strconv.Itoa(cfg.Health.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}
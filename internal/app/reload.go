@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/logger"
+)
+
+// reinitLogger applies a reloaded log level; tests replace it
+var reinitLogger = initLogger
+
+// watchConfigReload reloads the hot-reloadable config settings on every
+// SIGHUP until ctx is done
+func watchConfigReload(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			reloadConfig(ctx)
+		}
+	}
+}
+
+func reloadConfig(ctx context.Context) {
+	log := logger.FromContext(ctx)
+	log.Info("Reloading config")
+	result, err := config.Reload()
+	if err != nil {
+		log.Error("Config reload rejected, keeping current config: ", err)
+		return
+	}
+
+	if slices.Contains(result.Applied, "elastic.level") {
+		reinitLogger()
+	}
+	if len(result.Applied) == 0 {
+		log.Info("Config reloaded, no reloadable settings changed")
+	} else {
+		log.Info("Config reloaded, applied: ", result.Applied)
+	}
+	if len(result.Ignored) > 0 {
+		log.Warn("Config changes that need a restart were ignored: ", result.Ignored)
+	}
+}
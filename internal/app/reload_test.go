@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/logger"
+)
+
+// writeConfig writes config.json into the test's working directory
+func writeConfig(t *testing.T, content string) {
+	t.Helper()
+	if err := os.WriteFile("config.json", []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSIGHUPReloadsConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	previousLogger := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previousLogger) })
+
+	running := &config.AppConfig{App: config.App{Port: 5000, EnabledModes: []string{"WORDLE"}}}
+	previousConfig := config.Set(running)
+	t.Cleanup(func() { config.Set(previousConfig) })
+
+	writeConfig(t, `{"app": {"port": 6000, "enabledModes": ["WORDLE", "RIDDLES"], "matchers": {"riddles": "exact"}}}`)
+
+	// Keep SIGHUP from terminating the test binary before the watcher registers
+	guard := make(chan os.Signal, 1)
+	signal.Notify(guard, syscall.SIGHUP)
+	t.Cleanup(func() { signal.Stop(guard) })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		watchConfigReload(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for config.Get() == running {
+		if time.Now().After(deadline) {
+			t.Fatal("config was not reloaded")
+		}
+		// Resend until the watcher has registered and picked one up
+		syscall.Kill(os.Getpid(), syscall.SIGHUP)
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	reloaded := config.Get()
+	if !slices.Equal(reloaded.App.EnabledModes, []string{"WORDLE", "RIDDLES"}) || reloaded.App.Matchers["riddles"] != "exact" {
+		t.Errorf("reloaded app config = %+v, want the new modes and matchers", reloaded.App)
+	}
+	if reloaded.App.Port != 5000 {
+		t.Errorf("port = %d, want 5000 kept until restart", reloaded.App.Port)
+	}
+	if !slices.Equal(running.App.EnabledModes, []string{"WORDLE"}) {
+		t.Errorf("previous config was modified: %+v", running.App)
+	}
+}
+
+func TestReloadRejectsInvalidConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	previousLogger := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previousLogger) })
+
+	running := &config.AppConfig{App: config.App{Port: 5000}}
+	previousConfig := config.Set(running)
+	t.Cleanup(func() { config.Set(previousConfig) })
+
+	writeConfig(t, `{"app": {"port": 6000, "enabledModes": ["NOT_A_MODE"]}}`)
+	reloadConfig(context.Background())
+
+	if config.Get() != running {
+		t.Error("an invalid config replaced the running one")
+	}
+}
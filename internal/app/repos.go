@@ -6,10 +6,14 @@ import (
 
 type Repos struct {
 	VirtualMachineRepo *database.VirtualMachineRepo
+	BuildRecordRepo    *database.BuildRecordRepo
+	VMLabelRepo        *database.VMLabelRepo
 }
 
 func NewRepos(dependencies *Dependencies) *Repos {
 	return &Repos{
 		VirtualMachineRepo: database.NewVirtualMachineRepo(dependencies.DatabaseClient.DB),
+		BuildRecordRepo:    database.NewBuildRecordRepo(dependencies.DatabaseClient.DB),
+		VMLabelRepo:        database.NewVMLabelRepo(dependencies.DatabaseClient.DB),
 	}
 }
@@ -4,16 +4,23 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
+	"time"
 
-	"github.com/cynxees/cynx-core/src/logger"
+	"github.com/cynxees/ra-server/internal/dependencies"
 	"github.com/cynxees/ra-server/internal/dependencies/config"
 	"github.com/cynxees/ra-server/internal/grpc"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/service/buildservice"
 	"golang.org/x/sync/errgroup"
 )
 
 type Servers struct {
-	grpcServer *grpc.Server
+	grpcServer     *grpc.Server
+	healthServer   *http.Server
+	buildService   *buildservice.Service
+	databaseClient *dependencies.DatabaseClient
 }
 
 func (app *App) NewServers() (*Servers, error) {
@@ -22,10 +29,22 @@ func (app *App) NewServers() (*Servers, error) {
 	// Create gRPC server
 	grpcServer := &grpc.Server{
 		VirtualMachineService: services.VirtualMachineService,
+		HealthService:         services.HealthService,
+		BuildService:          services.BuildService,
+		ModeService:           services.ModeService,
+		Config:                config.Get().Grpc,
+	}
+
+	var healthServer *http.Server
+	if config.Get().Health.Port != 0 {
+		healthServer = newHealthServer(services.HealthService)
 	}
 
 	return &Servers{
-		grpcServer: grpcServer,
+		grpcServer:     grpcServer,
+		healthServer:   healthServer,
+		buildService:   services.BuildService,
+		databaseClient: app.Dependencies.DatabaseClient,
 	}, nil
 }
 
@@ -33,17 +52,129 @@ func (s *Servers) Start(ctx context.Context) error {
 	var g errgroup.Group
 
 	g.Go(func() error {
-		logger.Info(ctx, "Starting gRPC server")
-		address := config.Config.App.Address + ":" + strconv.Itoa(config.Config.App.Port)
-		if err := s.grpcServer.Start(ctx, address); err != nil {
+		logger.FromContext(ctx).Info("Starting gRPC server")
+		app := config.Get().App
+		address := app.Address + ":" + strconv.Itoa(app.Port)
+		if err := superviseServer(ctx, "gRPC", func() error {
+			return s.grpcServer.Start(ctx, address)
+		}); err != nil {
 			return fmt.Errorf("failed to start gRPC server: %w", err)
 		}
 		return nil
 	})
 
+	if s.healthServer != nil {
+		g.Go(func() error {
+			logger.FromContext(ctx).Info("Starting health server on ", s.healthServer.Addr)
+			if err := s.healthServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("failed to start health server: %w", err)
+			}
+			return nil
+		})
+	}
+
 	return g.Wait()
 }
 
-func (s *Servers) Stop() error {
-	return errors.New("stop not implemented")
+const (
+	maxServerRestarts    = 5
+	serverRestartBackoff = time.Second
+)
+
+// restartAfter waits out the restart backoff; tests replace it to skip the wait
+var restartAfter = time.After
+
+// superviseServer runs start and restarts it with exponential backoff when it
+// fails unexpectedly. A nil error means the server was stopped gracefully and
+// is not restarted, and neither is anything once ctx is done.
+func superviseServer(ctx context.Context, name string, start func() error) error {
+	backoff := serverRestartBackoff
+	for restarts := 0; ; restarts++ {
+		err := start()
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		if restarts >= maxServerRestarts {
+			return fmt.Errorf("giving up after %d restarts: %w", restarts, err)
+		}
+
+		logger.FromContext(ctx).Warn(name, " server stopped unexpectedly, restarting in ", backoff, ": ", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-restartAfter(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// ShutdownSummary records what Stop managed to shut down and how long it took.
+// It has no VM count because Stop leaves VMs alone: their LXC containers keep
+// running without the server, and ReconcileVirtualMachines resyncs them.
+type ShutdownSummary struct {
+	Errors         []string
+	Duration       time.Duration
+	GrpcStopped    bool
+	HealthStopped  bool
+	BuildsStopped  bool
+	DatabaseClosed bool
+}
+
+func (s ShutdownSummary) String() string {
+	return fmt.Sprintf("duration=%s grpcStopped=%t healthStopped=%t buildsStopped=%t databaseClosed=%t errors=%d",
+		s.Duration, s.GrpcStopped, s.HealthStopped, s.BuildsStopped, s.DatabaseClosed, len(s.Errors))
+}
+
+// Stop drains the gRPC server, shuts down the health server, stops builds and
+// closes the database, in that order, then logs a summary. Servers still
+// running when ctx is done are closed forcibly. Every step runs even if an
+// earlier one fails.
+func (s *Servers) Stop(ctx context.Context) (ShutdownSummary, error) {
+	start := time.Now()
+	var summary ShutdownSummary
+	var errs []error
+	log := logger.FromContext(ctx)
+
+	log.Info("Stopping gRPC server")
+	summary.GrpcStopped = s.grpcServer.Stop(ctx)
+
+	if s.healthServer != nil {
+		log.Info("Stopping health server")
+		if err := s.healthServer.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop health server: %w", err))
+		} else {
+			summary.HealthStopped = true
+		}
+	}
+
+	if s.buildService != nil {
+		// Builds record their outcome in the database, so they stop before it closes
+		log.Info("Stopping builds")
+		if err := s.buildService.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop builds: %w", err))
+		} else {
+			summary.BuildsStopped = true
+		}
+	}
+
+	if s.databaseClient != nil {
+		log.Info("Closing database")
+		if err := s.databaseClient.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close database: %w", err))
+		} else {
+			summary.DatabaseClosed = true
+		}
+	}
+
+	for _, err := range errs {
+		summary.Errors = append(summary.Errors, err.Error())
+	}
+	summary.Duration = time.Since(start)
+
+	if len(errs) > 0 {
+		log.Warn("Shutdown finished with errors: ", summary, " ", summary.Errors)
+	} else {
+		log.Info("Shutdown finished: ", summary)
+	}
+	return summary, errors.Join(errs...)
 }
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/dependencies"
+	"github.com/cynxees/ra-server/internal/grpc"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/service/buildservice"
+	"github.com/cynxees/ra-server/internal/testutil"
+)
+
+func TestStopReportsSummary(t *testing.T) {
+	previous := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+
+	db := testutil.NewDB(t)
+	servers := &Servers{
+		grpcServer:     &grpc.Server{},
+		healthServer:   &http.Server{},
+		buildService:   &buildservice.Service{},
+		databaseClient: &dependencies.DatabaseClient{DB: db},
+	}
+
+	summary, err := servers.Stop(context.Background())
+	if err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	// The gRPC server was never started, so there was nothing to drain
+	if summary.GrpcStopped {
+		t.Error("GrpcStopped = true for a server that never started")
+	}
+	if !summary.HealthStopped || !summary.BuildsStopped || !summary.DatabaseClosed {
+		t.Errorf("summary = %s, want health, builds and database stopped", summary)
+	}
+	if summary.Duration <= 0 {
+		t.Errorf("Duration = %s, want it measured", summary.Duration)
+	}
+	if len(summary.Errors) != 0 {
+		t.Errorf("Errors = %v, want none", summary.Errors)
+	}
+	if err := db.Exec("SELECT 1").Error; err == nil {
+		t.Error("database still usable after Stop")
+	}
+}
+
+// stubRestartAfter records each backoff superviseServer waits for and skips
+// the wait
+func stubRestartAfter(t *testing.T) *[]time.Duration {
+	t.Helper()
+	previousAfter := restartAfter
+	previousWriter := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() {
+		restartAfter = previousAfter
+		logger.SetWriter(previousWriter)
+	})
+
+	waits := &[]time.Duration{}
+	restartAfter = func(d time.Duration) <-chan time.Time {
+		*waits = append(*waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Time{}
+		return ch
+	}
+	return waits
+}
+
+func TestSuperviseServerRestartsWithBackoff(t *testing.T) {
+	waits := stubRestartAfter(t)
+
+	starts := 0
+	err := superviseServer(context.Background(), "test", func() error {
+		starts++
+		if starts < 4 {
+			return errors.New("listener closed")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("superviseServer() error = %v", err)
+	}
+	if starts != 4 {
+		t.Errorf("start called %d times, want 4", starts)
+	}
+	want := []time.Duration{serverRestartBackoff, 2 * serverRestartBackoff, 4 * serverRestartBackoff}
+	if !slices.Equal(*waits, want) {
+		t.Errorf("backoff = %v, want %v", *waits, want)
+	}
+}
+
+func TestSuperviseServerGivesUp(t *testing.T) {
+	stubRestartAfter(t)
+
+	starts := 0
+	err := superviseServer(context.Background(), "test", func() error {
+		starts++
+		return errors.New("listener closed")
+	})
+	if err == nil || !strings.Contains(err.Error(), "giving up after 5 restarts") {
+		t.Errorf("superviseServer() error = %v, want it to give up", err)
+	}
+	if starts != maxServerRestarts+1 {
+		t.Errorf("start called %d times, want %d", starts, maxServerRestarts+1)
+	}
+}
+
+func TestSuperviseServerStopsOnCancel(t *testing.T) {
+	stubRestartAfter(t)
+	backingOff := make(chan struct{})
+	restartAfter = func(time.Duration) <-chan time.Time {
+		close(backingOff)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- superviseServer(ctx, "test", func() error {
+			return errors.New("listener closed")
+		})
+	}()
+
+	// Cancel while the first backoff is in progress
+	<-backingOff
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("superviseServer() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("superviseServer did not stop after cancel")
+	}
+}
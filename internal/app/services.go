@@ -1,17 +1,32 @@
 package app
 
 import (
+	"github.com/cynxees/ra-server/internal/service/buildservice"
+	"github.com/cynxees/ra-server/internal/service/healthservice"
+	"github.com/cynxees/ra-server/internal/service/modeservice"
 	"github.com/cynxees/ra-server/internal/service/virtualmachineservice"
 )
 
 type Services struct {
 	VirtualMachineService *virtualmachineservice.Service
+	HealthService         *healthservice.Service
+	BuildService          *buildservice.Service
+	ModeService           *modeservice.Service
 }
 
-func NewServices(repos *Repos) *Services {
+func NewServices(dependencies *Dependencies, repos *Repos) *Services {
 	return &Services{
 		VirtualMachineService: &virtualmachineservice.Service{
 			VirtualMachineRepo: repos.VirtualMachineRepo,
+			VMLabelRepo:        repos.VMLabelRepo,
 		},
+		HealthService: &healthservice.Service{
+			DatabaseClient: dependencies.DatabaseClient,
+		},
+		BuildService: &buildservice.Service{
+			BuildRecordRepo:    repos.BuildRecordRepo,
+			VirtualMachineRepo: repos.VirtualMachineRepo,
+		},
+		ModeService: &modeservice.Service{},
 	}
 }
@@ -0,0 +1,17 @@
+package constant
+
+type BuildKind string
+
+const (
+	BuildKindLXC   BuildKind = "LXC"
+	BuildKindQCOW2 BuildKind = "QCOW2"
+)
+
+type BuildStatus string
+
+const (
+	BuildStatusPending BuildStatus = "PENDING"
+	BuildStatusRunning BuildStatus = "RUNNING"
+	BuildStatusDone    BuildStatus = "DONE"
+	BuildStatusFailed  BuildStatus = "FAILED"
+)
@@ -1,5 +1,11 @@
 package constant
 
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
 type ModeType string
 
 const (
@@ -42,3 +48,137 @@ const (
 	ModeTypeInteractiveStory   ModeType = "INTERACTIVE_STORY"
 	ModeTypeCreativeWriting    ModeType = "CREATIVE_WRITING"
 )
+
+// ModeTypes lists every declared mode, in declaration order.
+var ModeTypes = []ModeType{
+	ModeTypeWordle,
+	ModeTypeSudoku,
+	ModeTypeHangman,
+	ModeTypeMemory,
+	ModeTypeQuiz,
+	ModeTypeCrossword,
+	ModeTypePuzzle,
+	ModeTypeTrivia,
+	ModeTypeFlashcards,
+	ModeTypeMatching,
+	ModeTypeFillInTheBlank,
+	ModeTypeMultipleChoice,
+	ModeTypeTrueFalse,
+	ModeTypeSorting,
+	ModeTypeSequence,
+	ModeTypeWordSearch,
+	ModeTypeAnagram,
+	ModeTypeRiddles,
+	ModeTypeLogicPuzzle,
+	ModeTypeMathPuzzle,
+	ModeTypeVisualPuzzle,
+	ModeTypeAudioPuzzle,
+	ModeTypeCodePuzzle,
+	ModeTypeEscapeRoom,
+	ModeTypeScavengerHunt,
+	ModeTypeStoryPuzzle,
+	ModeTypeWordAssociation,
+	ModeTypeNumberPuzzle,
+	ModeTypePatternRecognition,
+	ModeTypeTriviaChallenge,
+	ModeTypeFlashQuiz,
+	ModeTypeInteractiveStory,
+	ModeTypeCreativeWriting,
+}
+
+const (
+	ModeCategoryWord          = "word"
+	ModeCategoryPuzzle        = "puzzle"
+	ModeCategoryQuiz          = "quiz"
+	ModeCategoryMemory        = "memory"
+	ModeCategoryAdventure     = "adventure"
+	ModeCategoryStory         = "story"
+	ModeCategoryUncategorised = "uncategorised"
+)
+
+var modeCategories = map[ModeType]string{
+	ModeTypeWordle:          ModeCategoryWord,
+	ModeTypeHangman:         ModeCategoryWord,
+	ModeTypeCrossword:       ModeCategoryWord,
+	ModeTypeWordSearch:      ModeCategoryWord,
+	ModeTypeAnagram:         ModeCategoryWord,
+	ModeTypeWordAssociation: ModeCategoryWord,
+	ModeTypeFillInTheBlank:  ModeCategoryWord,
+
+	ModeTypeSudoku:             ModeCategoryPuzzle,
+	ModeTypePuzzle:             ModeCategoryPuzzle,
+	ModeTypeRiddles:            ModeCategoryPuzzle,
+	ModeTypeLogicPuzzle:        ModeCategoryPuzzle,
+	ModeTypeMathPuzzle:         ModeCategoryPuzzle,
+	ModeTypeVisualPuzzle:       ModeCategoryPuzzle,
+	ModeTypeAudioPuzzle:        ModeCategoryPuzzle,
+	ModeTypeCodePuzzle:         ModeCategoryPuzzle,
+	ModeTypeNumberPuzzle:       ModeCategoryPuzzle,
+	ModeTypePatternRecognition: ModeCategoryPuzzle,
+
+	ModeTypeQuiz:            ModeCategoryQuiz,
+	ModeTypeTrivia:          ModeCategoryQuiz,
+	ModeTypeTriviaChallenge: ModeCategoryQuiz,
+	ModeTypeMultipleChoice:  ModeCategoryQuiz,
+	ModeTypeTrueFalse:       ModeCategoryQuiz,
+	ModeTypeFlashQuiz:       ModeCategoryQuiz,
+
+	ModeTypeMemory:     ModeCategoryMemory,
+	ModeTypeFlashcards: ModeCategoryMemory,
+	ModeTypeMatching:   ModeCategoryMemory,
+	ModeTypeSorting:    ModeCategoryMemory,
+	ModeTypeSequence:   ModeCategoryMemory,
+
+	ModeTypeEscapeRoom:    ModeCategoryAdventure,
+	ModeTypeScavengerHunt: ModeCategoryAdventure,
+
+	ModeTypeStoryPuzzle:      ModeCategoryStory,
+	ModeTypeInteractiveStory: ModeCategoryStory,
+	ModeTypeCreativeWriting:  ModeCategoryStory,
+}
+
+// Category returns the menu group the mode belongs to.
+func (m ModeType) Category() string {
+	if category, ok := modeCategories[m]; ok {
+		return category
+	}
+	return ModeCategoryUncategorised
+}
+
+// ModesByCategory groups ModeTypes by their Category, preserving declaration order.
+func ModesByCategory() map[string][]ModeType {
+	result := make(map[string][]ModeType)
+	for _, mode := range ModeTypes {
+		category := mode.Category()
+		result[category] = append(result[category], mode)
+	}
+	return result
+}
+
+// IsValid reports whether m is one of the declared ModeTypes.
+func (m ModeType) IsValid() bool {
+	return slices.Contains(ModeTypes, m)
+}
+
+// MarshalJSON encodes the mode as its string. It does not validate, so a
+// struct with an unset mode still marshals, as "".
+func (m ModeType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(m))
+}
+
+// UnmarshalJSON rejects unknown or empty modes so request bodies are
+// validated at decode time.
+func (m *ModeType) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("mode type must be a string: %w", err)
+	}
+
+	mode := ModeType(raw)
+	if !mode.IsValid() {
+		return fmt.Errorf("invalid mode type %q", raw)
+	}
+
+	*m = mode
+	return nil
+}
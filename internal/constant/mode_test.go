@@ -0,0 +1,104 @@
+package constant
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestModeTypeUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    ModeType
+		wantErr bool
+	}{
+		{name: "valid", input: `"` + string(ModeTypes[0]) + `"`, want: ModeTypes[0]},
+		{name: "unknown", input: `"not-a-mode"`, wantErr: true},
+		{name: "empty", input: `""`, wantErr: true},
+		{name: "not a string", input: `3`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got ModeType
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Unmarshal(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Unmarshal(%s) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModeTypeMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		mode ModeType
+		want string
+	}{
+		{name: "valid", mode: ModeTypes[0], want: `"` + string(ModeTypes[0]) + `"`},
+		{name: "unset", mode: "", want: `""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.mode)
+			if err != nil {
+				t.Fatalf("Marshal(%q): %v", tt.mode, err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal(%q) = %s, want %s", tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModeTypeMarshalJSONUnsetField(t *testing.T) {
+	type request struct {
+		Mode ModeType `json:"mode"`
+	}
+
+	got, err := json.Marshal(request{})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(got) != `{"mode":""}` {
+		t.Errorf("Marshal = %s, want {\"mode\":\"\"}", got)
+	}
+}
+
+func TestEveryModeHasACategory(t *testing.T) {
+	for _, mode := range ModeTypes {
+		if category := mode.Category(); category == ModeCategoryUncategorised {
+			t.Errorf("%s has no category", mode)
+		}
+	}
+	if category := ModeType("NOT_A_MODE").Category(); category != ModeCategoryUncategorised {
+		t.Errorf("unknown mode category = %q, want %q", category, ModeCategoryUncategorised)
+	}
+}
+
+func TestModesByCategory(t *testing.T) {
+	groups := ModesByCategory()
+
+	total := 0
+	for category, modes := range groups {
+		total += len(modes)
+		for _, mode := range modes {
+			if mode.Category() != category {
+				t.Errorf("%s grouped under %q, but its category is %q", mode, category, mode.Category())
+			}
+		}
+	}
+	if total != len(ModeTypes) {
+		t.Errorf("grouped %d modes, want all %d", total, len(ModeTypes))
+	}
+
+	// Declaration order is kept within a group
+	word := groups[ModeCategoryWord]
+	if len(word) < 2 || word[0] != ModeTypeWordle || word[1] != ModeTypeHangman {
+		t.Errorf("word modes = %v, want WORDLE then HANGMAN first", word)
+	}
+}
@@ -0,0 +1,7 @@
+package constant
+
+const (
+	VirtualMachineStatusInactive     = "inactive"
+	VirtualMachineStatusProvisioning = "provisioning"
+	VirtualMachineStatusRunning      = "running"
+)
@@ -1,40 +1,138 @@
 package config
 
-import "github.com/cynxees/cynx-core/src/configuration"
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
 
-var Config *AppConfig
+	"github.com/cynxees/cynx-core/src/configuration"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/helper"
+)
+
+// current holds the running config. Readers go through Get so Reload can swap
+// it while requests are in flight.
+var current atomic.Pointer[AppConfig]
+
+const configPath = "config.json"
+
+// Get returns the running config. It is shared, so callers must not modify
+// it; Reload replaces it instead of changing it in place.
+func Get() *AppConfig {
+	return current.Load()
+}
+
+// Set replaces the running config and returns the previous one
+func Set(cfg *AppConfig) *AppConfig {
+	return current.Swap(cfg)
+}
 
 type AppConfig struct {
 	Aws      AwsConfig      `mapstructure:"aws"`
 	Elastic  ElasticConfig  `mapstructure:"elastic"`
+	Health   HealthConfig   `mapstructure:"health"`
+	Grpc     GrpcConfig     `mapstructure:"grpc"`
 	App      App            `mapstructure:"app"`
 	Database DatabaseConfig `mapstructure:"database"`
+	// VirtualMachine bounds the resources a VM may be given
+	VirtualMachine VirtualMachineConfig `mapstructure:"virtualMachine"`
 }
 
 type App struct {
 	Name    string `mapstructure:"name"`
 	Address string `mapstructure:"address"`
 	Key     string `mapstructure:"key"`
-	Port    int    `mapstructure:"port"`
-	Debug   bool   `mapstructure:"debug"`
+	// Matchers overrides the answer matcher per mode, e.g. {"RIDDLES": "fuzzy:0.8"}.
+	// Mode keys are matched case-insensitively since viper lowercases map keys.
+	Matchers map[string]string `mapstructure:"matchers"`
+	// EnabledModes restricts the game modes ModeService lists and accepts; empty means all modes.
+	EnabledModes []string `mapstructure:"enabledModes"`
+	Port         int      `mapstructure:"port"`
+	Debug        bool     `mapstructure:"debug"`
 }
 
-type DatabaseConfig struct {
-	Host        string `mapstructure:"host"`
-	Database    string `mapstructure:"database"`
-	Username    string `mapstructure:"username"`
-	Password    string `mapstructure:"password"`
-	Dialect     string `mapstructure:"dialect"`
-	AutoMigrate bool   `mapstructure:"autoMigrate"`
-	Pool        struct {
-		Max     int `mapstructure:"max"`
-		Min     int `mapstructure:"min"`
-		Acquire int `mapstructure:"acquire"`
-		Idle    int `mapstructure:"idle"`
-	} `mapstructure:"pool"`
+// IsModeEnabled reports whether mode may be served under the EnabledModes restriction.
+func (a App) IsModeEnabled(mode constant.ModeType) bool {
+	if len(a.EnabledModes) == 0 {
+		return mode.IsValid()
+	}
+	for _, enabled := range a.EnabledModes {
+		if constant.ModeType(enabled) == mode {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultMatchers picks a matcher for modes without an override; other modes
+// fall back to case-insensitive matching.
+var defaultMatchers = map[constant.ModeType]string{
+	constant.ModeTypeWordle:  "exact",
+	constant.ModeTypeSudoku:  "exact",
+	constant.ModeTypeRiddles: "fuzzy:0.8",
+	constant.ModeTypeTrivia:  "fuzzy:0.9",
+}
+
+// MatcherFor returns the answer matcher configured for mode.
+func (a App) MatcherFor(mode constant.ModeType) (helper.Matcher, error) {
+	spec, ok := defaultMatchers[mode]
+	for key, override := range a.Matchers {
+		if strings.EqualFold(key, string(mode)) {
+			spec, ok = override, true
+		}
+	}
+	if !ok {
+		return helper.CaseInsensitiveMatcher{}, nil
+	}
+	return helper.ParseMatcher(spec)
+}
+
+type HealthConfig struct {
+	// BuildDir is checked for free space, defaulting to sandbox/build
+	BuildDir      string `mapstructure:"buildDir"`
+	MinFreeDiskMb int    `mapstructure:"minFreeDiskMb"`
+	// Port serves HTTP /healthz when set
 	Port int `mapstructure:"port"`
 }
 
+type DatabaseConfig struct {
+	Host     string `mapstructure:"host"`
+	Database string `mapstructure:"database"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	Dialect  string `mapstructure:"dialect"`
+	// Charset defaults to utf8mb4; Collation and Location are left to the server/driver when empty
+	Charset   string `mapstructure:"charset"`
+	Collation string `mapstructure:"collation"`
+	Location  string `mapstructure:"location"`
+	// Replicas receive read queries; they share the primary's credentials and database
+	Replicas    []DatabaseReplicaConfig `mapstructure:"replicas"`
+	AutoMigrate bool                    `mapstructure:"autoMigrate"`
+	// DryRunMigrate logs pending schema changes at startup without applying them
+	DryRunMigrate bool               `mapstructure:"dryRunMigrate"`
+	Pool          DatabasePoolConfig `mapstructure:"pool"`
+	Port          int                `mapstructure:"port"`
+}
+
+// DatabasePoolConfig sizes the connection pool of the primary and every
+// replica. Durations are in milliseconds and zero keeps the database/sql default.
+type DatabasePoolConfig struct {
+	// Max caps open connections and Min is how many idle ones are kept
+	Max int `mapstructure:"max"`
+	Min int `mapstructure:"min"`
+	// Acquire has no database/sql equivalent and is not applied
+	Acquire int `mapstructure:"acquire"`
+	// Idle closes connections unused for this long; Lifetime closes them at this age
+	Idle     int `mapstructure:"idle"`
+	Lifetime int `mapstructure:"lifetime"`
+}
+
+type DatabaseReplicaConfig struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port"`
+}
+
 type ElasticConfig struct {
 	Url   string `json:"url"`
 	Level string `json:"level"`
@@ -49,9 +147,86 @@ type AwsConfig struct {
 }
 
 func InitConfig() {
-	Config = &AppConfig{}
-	err := configuration.InitConfig("config.json", Config)
+	cfg := &AppConfig{}
+	err := configuration.InitConfig(configPath, cfg)
 	if err != nil {
 		panic("failed to initialize config: " + err.Error())
 	}
+
+	if err := cfg.Validate(); err != nil {
+		panic("invalid config: " + err.Error())
+	}
+	Set(cfg)
+}
+
+type namedPort struct {
+	name string
+	port int
+}
+
+// listenPorts returns every port this process binds locally, so Validate can
+// reject collisions before any listener is opened.
+func (c *AppConfig) listenPorts() []namedPort {
+	ports := []namedPort{
+		{name: "app.port", port: c.App.Port},
+	}
+	if c.Health.Port != 0 {
+		ports = append(ports, namedPort{name: "health.port", port: c.Health.Port})
+	}
+	return ports
+}
+
+// Validate checks cross-field constraints that cannot be expressed through
+// the config tags alone.
+func (c *AppConfig) Validate() error {
+	seen := make(map[int]string)
+	for _, p := range c.listenPorts() {
+		if p.port < 1 || p.port > 65535 {
+			return fmt.Errorf("%s %d is out of range", p.name, p.port)
+		}
+		if other, ok := seen[p.port]; ok {
+			return fmt.Errorf("%s and %s both use port %d", other, p.name, p.port)
+		}
+		seen[p.port] = p.name
+	}
+
+	if c.Database.Location != "" {
+		if _, err := time.LoadLocation(c.Database.Location); err != nil {
+			return fmt.Errorf("database.location %q is not a valid time zone: %w", c.Database.Location, err)
+		}
+	}
+
+	if err := c.Grpc.validate(); err != nil {
+		return fmt.Errorf("grpc: %w", err)
+	}
+
+	if err := c.VirtualMachine.validate(); err != nil {
+		return fmt.Errorf("virtualMachine: %w", err)
+	}
+
+	for i, replica := range c.Database.Replicas {
+		if replica.Host == "" {
+			return fmt.Errorf("database.replicas[%d].host is required", i)
+		}
+		if replica.Port < 1 || replica.Port > 65535 {
+			return fmt.Errorf("database.replicas[%d].port %d is out of range", i, replica.Port)
+		}
+	}
+
+	for _, mode := range c.App.EnabledModes {
+		if !constant.ModeType(mode).IsValid() {
+			return fmt.Errorf("app.enabledModes contains unknown mode %q", mode)
+		}
+	}
+
+	for mode, spec := range c.App.Matchers {
+		if !constant.ModeType(strings.ToUpper(mode)).IsValid() {
+			return fmt.Errorf("app.matchers contains unknown mode %q", mode)
+		}
+		if _, err := helper.ParseMatcher(spec); err != nil {
+			return fmt.Errorf("app.matchers.%s: %w", mode, err)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr string
+		app     int
+		health  int
+	}{
+		{name: "distinct", app: 5000, health: 5001},
+		{name: "health disabled", app: 5000},
+		{name: "shared", app: 5000, health: 5000, wantErr: "app.port and health.port both use port 5000"},
+		{name: "app out of range", app: 70000, wantErr: "app.port 70000 is out of range"},
+		{name: "app unset", wantErr: "app.port 0 is out of range"},
+		{name: "health out of range", app: 5000, health: -1, wantErr: "health.port -1 is out of range"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &AppConfig{App: App{Port: tt.app}, Health: HealthConfig{Port: tt.health}}
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
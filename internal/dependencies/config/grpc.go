@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// GrpcConfig tunes the gRPC server. Zero values keep grpc-go's defaults:
+// 4 MiB receive limit, unlimited send size, 2h keepalive with a 20s timeout
+// and no idle limit. Reflection and panic recovery are on unless disabled.
+type GrpcConfig struct {
+	Tls TLSConfig `mapstructure:"tls"`
+	// MaxRecvMsgBytes and MaxSendMsgBytes cap message sizes
+	MaxRecvMsgBytes int `mapstructure:"maxRecvMsgBytes"`
+	MaxSendMsgBytes int `mapstructure:"maxSendMsgBytes"`
+	// KeepaliveTimeSec pings idle clients after this long; KeepaliveTimeoutSec waits this long for the ack
+	KeepaliveTimeSec    int `mapstructure:"keepaliveTimeSec"`
+	KeepaliveTimeoutSec int `mapstructure:"keepaliveTimeoutSec"`
+	// MaxConnectionIdleSec closes connections without RPCs after this long
+	MaxConnectionIdleSec int  `mapstructure:"maxConnectionIdleSec"`
+	DisableReflection    bool `mapstructure:"disableReflection"`
+	// DisableRecovery lets handler panics crash the process, e.g. while debugging
+	DisableRecovery bool `mapstructure:"disableRecovery"`
+}
+
+func (g GrpcConfig) KeepaliveTime() time.Duration {
+	return time.Duration(g.KeepaliveTimeSec) * time.Second
+}
+
+func (g GrpcConfig) KeepaliveTimeout() time.Duration {
+	return time.Duration(g.KeepaliveTimeoutSec) * time.Second
+}
+
+func (g GrpcConfig) MaxConnectionIdle() time.Duration {
+	return time.Duration(g.MaxConnectionIdleSec) * time.Second
+}
+
+func (g GrpcConfig) validate() error {
+	for name, value := range map[string]int{
+		"maxRecvMsgBytes":      g.MaxRecvMsgBytes,
+		"maxSendMsgBytes":      g.MaxSendMsgBytes,
+		"keepaliveTimeSec":     g.KeepaliveTimeSec,
+		"keepaliveTimeoutSec":  g.KeepaliveTimeoutSec,
+		"maxConnectionIdleSec": g.MaxConnectionIdleSec,
+	} {
+		if value < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+
+	if err := g.Tls.validate(); err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/cynxees/cynx-core/src/configuration"
+)
+
+// ReloadResult lists the settings a reload changed. Applied ones took effect;
+// Ignored ones differ in the file but only take effect after a restart.
+type ReloadResult struct {
+	Applied []string
+	Ignored []string
+}
+
+// loadConfig reads path into a fresh AppConfig, turning the panic
+// configuration.InitConfig raises for unreadable files into an error
+func loadConfig(path string) (cfg *AppConfig, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			cfg, err = nil, fmt.Errorf("%v", r)
+		}
+	}()
+
+	cfg = &AppConfig{}
+	if err := configuration.InitConfig(path, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// reloadMu serializes reloads so one never merges onto a config another is replacing
+var reloadMu sync.Mutex
+
+// Reload re-reads config.json and swaps in the settings that are safe to
+// change while running: the log level, enabled modes and matchers.
+// Everything else keeps its current value. The new file is validated first,
+// so a broken edit leaves the running config untouched.
+func Reload() (ReloadResult, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	next, err := loadConfig(configPath)
+	if err != nil {
+		return ReloadResult{}, fmt.Errorf("failed to read config: %w", err)
+	}
+	if err := next.Validate(); err != nil {
+		return ReloadResult{}, fmt.Errorf("invalid config: %w", err)
+	}
+
+	merged, result := mergeReloadable(Get(), next)
+	Set(merged)
+	return result, nil
+}
+
+// mergeReloadable returns a copy of current with the hot-reloadable fields
+// taken from next, reporting which fields changed
+func mergeReloadable(current, next *AppConfig) (*AppConfig, ReloadResult) {
+	var result ReloadResult
+	merged := *current
+
+	changed := func(name string, a, b any) bool {
+		if reflect.DeepEqual(a, b) {
+			return false
+		}
+		result.Applied = append(result.Applied, name)
+		return true
+	}
+	if changed("elastic.level", current.Elastic.Level, next.Elastic.Level) {
+		merged.Elastic.Level = next.Elastic.Level
+	}
+	if changed("app.enabledModes", current.App.EnabledModes, next.App.EnabledModes) {
+		merged.App.EnabledModes = next.App.EnabledModes
+	}
+	if changed("app.matchers", current.App.Matchers, next.App.Matchers) {
+		merged.App.Matchers = next.App.Matchers
+	}
+
+	ignore := func(name string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			result.Ignored = append(result.Ignored, name)
+		}
+	}
+	ignore("app.name", merged.App.Name, next.App.Name)
+	ignore("app.address", merged.App.Address, next.App.Address)
+	ignore("app.key", merged.App.Key, next.App.Key)
+	ignore("app.port", merged.App.Port, next.App.Port)
+	ignore("elastic.url", merged.Elastic.Url, next.Elastic.Url)
+	ignore("aws", merged.Aws, next.Aws)
+	ignore("health", merged.Health, next.Health)
+	ignore("grpc", merged.Grpc, next.Grpc)
+	ignore("database", merged.Database, next.Database)
+	ignore("virtualMachine", merged.VirtualMachine, next.VirtualMachine)
+
+	return &merged, result
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"crypto/tls"
+	"fmt"
+	"slices"
+)
+
+type TLSConfig struct {
+	// CertFile and KeyFile enable TLS when both are set
+	CertFile string `mapstructure:"certFile"`
+	KeyFile  string `mapstructure:"keyFile"`
+	// MinVersion is "1.2" (default) or "1.3"
+	MinVersion string `mapstructure:"minVersion"`
+	// CipherSuites restricts TLS 1.2 cipher suites by their Go names; empty keeps Go's secure defaults
+	CipherSuites []string `mapstructure:"cipherSuites"`
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+func (t TLSConfig) Enabled() bool {
+	return t.CertFile != "" || t.KeyFile != ""
+}
+
+// parse resolves MinVersion and CipherSuites without touching the key pair
+func (t TLSConfig) parse() (uint16, []uint16, error) {
+	minVersion := uint16(tls.VersionTLS12)
+	if t.MinVersion != "" {
+		version, ok := tlsVersions[t.MinVersion]
+		if !ok {
+			return 0, nil, fmt.Errorf("unsupported minVersion %q, expected 1.2 or 1.3", t.MinVersion)
+		}
+		minVersion = version
+	}
+
+	var suites []uint16
+	for _, name := range t.CipherSuites {
+		// Only suites Go considers secure are accepted; TLS 1.3 suites are not configurable
+		idx := slices.IndexFunc(tls.CipherSuites(), func(s *tls.CipherSuite) bool { return s.Name == name })
+		if idx < 0 {
+			return 0, nil, fmt.Errorf("unknown or insecure cipher suite %q", name)
+		}
+		suite := tls.CipherSuites()[idx]
+		if !slices.Contains(suite.SupportedVersions, tls.VersionTLS12) {
+			return 0, nil, fmt.Errorf("cipher suite %q is TLS 1.3 only and cannot be configured", name)
+		}
+		suites = append(suites, suite.ID)
+	}
+	if len(suites) > 0 && minVersion == tls.VersionTLS13 {
+		return 0, nil, fmt.Errorf("cipherSuites has no effect with minVersion 1.3")
+	}
+
+	return minVersion, suites, nil
+}
+
+func (t TLSConfig) validate() error {
+	if !t.Enabled() {
+		return nil
+	}
+	if t.CertFile == "" || t.KeyFile == "" {
+		return fmt.Errorf("certFile and keyFile must be set together")
+	}
+	_, _, err := t.parse()
+	return err
+}
+
+// ServerTLSConfig loads the key pair and returns the server's tls.Config, or nil when TLS is disabled
+func (t TLSConfig) ServerTLSConfig() (*tls.Config, error) {
+	if !t.Enabled() {
+		return nil, nil
+	}
+	minVersion, suites, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS key pair: %w", err)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   minVersion,
+		CipherSuites: suites,
+	}, nil
+}
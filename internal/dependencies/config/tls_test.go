@@ -0,0 +1,133 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeKeyPair writes a self-signed certificate and its key to a temporary directory
+func writeKeyPair(t *testing.T) (certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ra-server"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "server.crt"), filepath.Join(dir, "server.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t)
+	tests := []struct {
+		name           string
+		minVersion     string
+		cipherSuites   []string
+		wantSuites     []uint16
+		wantMinVersion uint16
+	}{
+		{name: "defaults", wantMinVersion: tls.VersionTLS12},
+		{name: "tls 1.3", minVersion: "1.3", wantMinVersion: tls.VersionTLS13},
+		{
+			name:           "restricted suites",
+			minVersion:     "1.2",
+			cipherSuites:   []string{"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384", "TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256"},
+			wantSuites:     []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384, tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256},
+			wantMinVersion: tls.VersionTLS12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := TLSConfig{CertFile: certFile, KeyFile: keyFile, MinVersion: tt.minVersion, CipherSuites: tt.cipherSuites}
+			tlsConfig, err := cfg.ServerTLSConfig()
+			if err != nil {
+				t.Fatalf("ServerTLSConfig() error = %v", err)
+			}
+			if tlsConfig.MinVersion != tt.wantMinVersion {
+				t.Errorf("MinVersion = %x, want %x", tlsConfig.MinVersion, tt.wantMinVersion)
+			}
+			if !slices.Equal(tlsConfig.CipherSuites, tt.wantSuites) {
+				t.Errorf("CipherSuites = %v, want %v", tlsConfig.CipherSuites, tt.wantSuites)
+			}
+			if len(tlsConfig.Certificates) != 1 {
+				t.Errorf("loaded %d certificates, want 1", len(tlsConfig.Certificates))
+			}
+		})
+	}
+}
+
+func TestServerTLSConfigDisabled(t *testing.T) {
+	tlsConfig, err := TLSConfig{MinVersion: "1.3"}.ServerTLSConfig()
+	if tlsConfig != nil || err != nil {
+		t.Errorf("ServerTLSConfig() = %v, %v, want TLS off", tlsConfig, err)
+	}
+}
+
+func TestTLSConfigRejects(t *testing.T) {
+	certFile, keyFile := writeKeyPair(t)
+	tests := []struct {
+		name      string
+		wantError string
+		config    TLSConfig
+	}{
+		{name: "unknown cipher", config: TLSConfig{CipherSuites: []string{"TLS_MADE_UP_SHA1"}}, wantError: `unknown or insecure cipher suite "TLS_MADE_UP_SHA1"`},
+		{name: "insecure cipher", config: TLSConfig{CipherSuites: []string{"TLS_RSA_WITH_RC4_128_SHA"}}, wantError: `unknown or insecure cipher suite "TLS_RSA_WITH_RC4_128_SHA"`},
+		{name: "tls 1.3 cipher", config: TLSConfig{CipherSuites: []string{"TLS_AES_128_GCM_SHA256"}}, wantError: "is TLS 1.3 only"},
+		{name: "ciphers with tls 1.3", config: TLSConfig{MinVersion: "1.3", CipherSuites: []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}}, wantError: "no effect with minVersion 1.3"},
+		{name: "tls 1.1", config: TLSConfig{MinVersion: "1.1"}, wantError: `unsupported minVersion "1.1"`},
+		{name: "cert without key", config: TLSConfig{CertFile: certFile}, wantError: "must be set together"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.config
+			if cfg.CertFile == "" {
+				cfg.CertFile, cfg.KeyFile = certFile, keyFile
+			}
+			if err := cfg.validate(); err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("validate() error = %v, want %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestServerTLSConfigMissingKeyPair(t *testing.T) {
+	dir := t.TempDir()
+	cfg := TLSConfig{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: filepath.Join(dir, "missing.key")}
+	if _, err := cfg.ServerTLSConfig(); err == nil || !strings.Contains(err.Error(), "failed to load TLS key pair") {
+		t.Errorf("ServerTLSConfig() error = %v, want a key pair error", err)
+	}
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/cynxees/ra-server/internal/model/entity"
+)
+
+// VirtualMachineConfig overrides the resource bounds VMs are validated
+// against. Zero values keep entity.DefaultVMResourceBounds.
+type VirtualMachineConfig struct {
+	MinCpus     int32 `mapstructure:"minCpus"`
+	MaxCpus     int32 `mapstructure:"maxCpus"`
+	MinMemoryMb int32 `mapstructure:"minMemoryMb"`
+	MaxMemoryMb int32 `mapstructure:"maxMemoryMb"`
+	MinDiskGb   int32 `mapstructure:"minDiskGb"`
+	MaxDiskGb   int32 `mapstructure:"maxDiskGb"`
+}
+
+// ResourceBounds returns the configured bounds with defaults filled in
+func (v VirtualMachineConfig) ResourceBounds() entity.VMResourceBounds {
+	bounds := entity.DefaultVMResourceBounds
+	if v.MinCpus != 0 {
+		bounds.MinCPUs = v.MinCpus
+	}
+	if v.MaxCpus != 0 {
+		bounds.MaxCPUs = v.MaxCpus
+	}
+	if v.MinMemoryMb != 0 {
+		bounds.MinMemoryMB = v.MinMemoryMb
+	}
+	if v.MaxMemoryMb != 0 {
+		bounds.MaxMemoryMB = v.MaxMemoryMb
+	}
+	if v.MinDiskGb != 0 {
+		bounds.MinDiskGB = v.MinDiskGb
+	}
+	if v.MaxDiskGb != 0 {
+		bounds.MaxDiskGB = v.MaxDiskGb
+	}
+	return bounds
+}
+
+func (v VirtualMachineConfig) validate() error {
+	bounds := v.ResourceBounds()
+	for _, r := range []struct {
+		name     string
+		min, max int32
+	}{
+		{"cpus", bounds.MinCPUs, bounds.MaxCPUs},
+		{"memoryMb", bounds.MinMemoryMB, bounds.MaxMemoryMB},
+		{"diskGb", bounds.MinDiskGB, bounds.MaxDiskGB},
+	} {
+		if r.min < 1 {
+			return fmt.Errorf("minimum %s must be at least 1, got %d", r.name, r.min)
+		}
+		if r.min > r.max {
+			return fmt.Errorf("minimum %s %d is above the maximum %d", r.name, r.min, r.max)
+		}
+	}
+	return nil
+}
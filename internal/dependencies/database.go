@@ -1,8 +1,14 @@
 package dependencies
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/cynxees/ra-server/internal/dependencies/config"
 	"github.com/cynxees/ra-server/internal/model/entity"
@@ -10,21 +16,133 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
 )
 
 type DatabaseClient struct {
 	DB *gorm.DB
 }
 
+// buildDataSourceName constructs the MySQL DSN, defaulting the charset to utf8mb4
+func buildDataSourceName(cfg config.DatabaseConfig) string {
+	charset := cfg.Charset
+	if charset == "" {
+		charset = "utf8mb4"
+	}
+
+	params := url.Values{}
+	params.Set("charset", charset)
+	params.Set("parseTime", "true")
+	if cfg.Collation != "" {
+		params.Set("collation", cfg.Collation)
+	}
+	if cfg.Location != "" {
+		params.Set("loc", cfg.Location)
+	}
+
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?%s",
+		cfg.Username,
+		cfg.Password,
+		cfg.Host,
+		cfg.Port,
+		cfg.Database,
+		params.Encode(),
+	)
+}
+
+// maskDSN hides the password in a DSN built by buildDataSourceName
+func maskDSN(dsn string) string {
+	at := strings.LastIndex(dsn, "@tcp(")
+	if at < 0 {
+		return dsn
+	}
+	user, _, ok := strings.Cut(dsn[:at], ":")
+	if !ok {
+		return dsn
+	}
+	return user + ":***" + dsn[at:]
+}
+
+// maskDSNError redacts the password wherever err's message echoes the DSN's
+// credentials. The password is only replaced in its user:password@tcp( form,
+// so a short password never mangles the rest of the message. A redacted error
+// does not wrap the original, which would still carry the password.
+func maskDSNError(err error, cfg config.DatabaseConfig) error {
+	if err == nil || cfg.Password == "" {
+		return err
+	}
+
+	credentials := cfg.Username + ":" + cfg.Password + "@tcp("
+	if !strings.Contains(err.Error(), credentials) {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), credentials, cfg.Username+":***@tcp("))
+}
+
+// openReplica is swapped out in tests
+var openReplica = mysql.Open
+
+// registerReplicas routes reads to the configured replicas and writes to the
+// primary. Use Clauses(dbresolver.Write) to force a read onto the primary.
+// Without replicas the single connection is left as is.
+func registerReplicas(db *gorm.DB, cfg config.DatabaseConfig) error {
+	if len(cfg.Replicas) == 0 {
+		return nil
+	}
+
+	replicas := make([]gorm.Dialector, 0, len(cfg.Replicas))
+	for _, replica := range cfg.Replicas {
+		replicaCfg := cfg
+		replicaCfg.Host = replica.Host
+		replicaCfg.Port = replica.Port
+		replicas = append(replicas, openReplica(buildDataSourceName(replicaCfg)))
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	})
+	// Replicas get their own pools, which only see settings made through the resolver
+	pool := cfg.Pool
+	if pool.Max > 0 {
+		resolver.SetMaxOpenConns(pool.Max)
+	}
+	if pool.Min > 0 {
+		resolver.SetMaxIdleConns(pool.Min)
+	}
+	if pool.Idle > 0 {
+		resolver.SetConnMaxIdleTime(time.Duration(pool.Idle) * time.Millisecond)
+	}
+	if pool.Lifetime > 0 {
+		resolver.SetConnMaxLifetime(time.Duration(pool.Lifetime) * time.Millisecond)
+	}
+
+	if err := db.Use(resolver); err != nil {
+		return fmt.Errorf("failed to register database replicas: %w", err)
+	}
+	return nil
+}
+
+// configurePool applies the pool settings to the primary's connections
+func configurePool(sqlDB *sql.DB, pool config.DatabasePoolConfig) {
+	if pool.Max > 0 {
+		sqlDB.SetMaxOpenConns(pool.Max)
+	}
+	if pool.Min > 0 {
+		sqlDB.SetMaxIdleConns(pool.Min)
+	}
+	if pool.Idle > 0 {
+		sqlDB.SetConnMaxIdleTime(time.Duration(pool.Idle) * time.Millisecond)
+	}
+	if pool.Lifetime > 0 {
+		sqlDB.SetConnMaxLifetime(time.Duration(pool.Lifetime) * time.Millisecond)
+	}
+}
+
 func NewDatabaseClient() (*DatabaseClient, error) {
 	// Construct the DSN (Data Source Name)
-	dataSourceName := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=true",
-		config.Config.Database.Username,
-		config.Config.Database.Password,
-		config.Config.Database.Host,
-		config.Config.Database.Port,
-		config.Config.Database.Database,
-	)
+	cfg := config.Get().Database
+	dataSourceName := buildDataSourceName(cfg)
 
 	// Open a connection with GORM using the MySQL driver
 	db, err := gorm.Open(mysql.Open(dataSourceName), &gorm.Config{
@@ -35,7 +153,11 @@ func NewDatabaseClient() (*DatabaseClient, error) {
 		QueryFields: true,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		return nil, fmt.Errorf("failed to connect to database: %w", maskDSNError(err, cfg))
+	}
+
+	if err := registerReplicas(db, cfg); err != nil {
+		return nil, maskDSNError(err, cfg)
 	}
 
 	// Check the connection
@@ -43,13 +165,29 @@ func NewDatabaseClient() (*DatabaseClient, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get generic database object: %w", err)
 	}
+	configurePool(sqlDB, cfg.Pool)
 	if err = sqlDB.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+		return nil, fmt.Errorf("failed to ping database: %w", maskDSNError(err, cfg))
 	}
 
 	return &DatabaseClient{DB: db}, nil
 }
 
+func (client *DatabaseClient) Ping(ctx context.Context) error {
+	sqlDB, err := client.DB.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get generic database object: %w", err)
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Transaction runs fn in a transaction, committing when it returns nil and
+// rolling back otherwise. Repos built on tx with their usual constructors,
+// e.g. database.NewVirtualMachineRepo(tx), all share the transaction.
+func (client *DatabaseClient) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
+	return client.DB.WithContext(ctx).Transaction(fn)
+}
+
 func (client *DatabaseClient) Close() error {
 	sqlDB, err := client.DB.DB()
 	if err != nil {
@@ -60,7 +198,7 @@ func (client *DatabaseClient) Close() error {
 
 func (client *DatabaseClient) RunMigrations() error {
 	log.Println("Running database migrations")
-	err := client.DB.AutoMigrate(&entity.VirtualMachine{})
+	err := client.DB.AutoMigrate(entity.MigrationModels()...)
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
@@ -68,3 +206,49 @@ func (client *DatabaseClient) RunMigrations() error {
 	log.Println("Migrations applied successfully")
 	return nil
 }
+
+// DryRunMigrate compares the live schema against the models and returns the
+// changes AutoMigrate would make, without applying anything. Columns that exist
+// in the database but not in the model are reported too, since AutoMigrate
+// never drops them and they usually mean schema drift.
+func (client *DatabaseClient) DryRunMigrate() ([]string, error) {
+	migrator := client.DB.Migrator()
+
+	var pending []string
+	for _, model := range entity.MigrationModels() {
+		stmt := &gorm.Statement{DB: client.DB}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+
+		if !migrator.HasTable(model) {
+			pending = append(pending, fmt.Sprintf("CREATE TABLE %s", table))
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		existing := make(map[string]bool, len(columnTypes))
+		for _, columnType := range columnTypes {
+			existing[columnType.Name()] = true
+		}
+
+		for _, dbName := range stmt.Schema.DBNames {
+			if !existing[dbName] {
+				field := stmt.Schema.FieldsByDBName[dbName]
+				pending = append(pending, fmt.Sprintf("ALTER TABLE %s ADD %s %s", table, dbName, migrator.FullDataTypeOf(field).SQL))
+			}
+		}
+
+		for _, columnType := range columnTypes {
+			if _, ok := stmt.Schema.FieldsByDBName[columnType.Name()]; !ok {
+				pending = append(pending, fmt.Sprintf("-- %s.%s is not in the model and will be left in place", table, columnType.Name()))
+			}
+		}
+	}
+
+	return pending, nil
+}
@@ -0,0 +1,245 @@
+package dependencies
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"github.com/cynxees/ra-server/internal/repository/database"
+	"github.com/cynxees/ra-server/internal/testutil"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
+)
+
+// useSQLiteReplicas opens replicas as in-memory SQLite databases, recording their DSNs
+func useSQLiteReplicas(t *testing.T) *[]string {
+	t.Helper()
+	var dsns []string
+	previous := openReplica
+	openReplica = func(dsn string) gorm.Dialector {
+		dsns = append(dsns, dsn)
+		return sqlite.Open(fmt.Sprintf("file:%s-replica%d?mode=memory&cache=shared", t.Name(), len(dsns)))
+	}
+	t.Cleanup(func() { openReplica = previous })
+	return &dsns
+}
+
+func TestRegisterReplicasWithoutReplicas(t *testing.T) {
+	db := testutil.NewDB(t)
+	useSQLiteReplicas(t)
+
+	if err := registerReplicas(db, config.DatabaseConfig{}); err != nil {
+		t.Fatalf("registerReplicas() error = %v", err)
+	}
+	if _, ok := db.Config.Plugins[(&dbresolver.DBResolver{}).Name()]; ok {
+		t.Error("resolver registered without replicas")
+	}
+}
+
+func TestRegisterReplicasAppliesPool(t *testing.T) {
+	db := testutil.NewDB(t)
+	dsns := useSQLiteReplicas(t)
+
+	cfg := config.DatabaseConfig{
+		Host:     "primary",
+		Replicas: []config.DatabaseReplicaConfig{{Host: "replica-a", Port: 3306}, {Host: "replica-b", Port: 3307}},
+		Pool:     config.DatabasePoolConfig{Max: 7, Min: 2, Idle: 1000, Lifetime: 60000},
+	}
+	if err := registerReplicas(db, cfg); err != nil {
+		t.Fatalf("registerReplicas() error = %v", err)
+	}
+
+	if len(*dsns) != 2 || !strings.Contains((*dsns)[0], "replica-a:3306") || !strings.Contains((*dsns)[1], "replica-b:3307") {
+		t.Fatalf("opened replicas %v, want replica-a and replica-b", *dsns)
+	}
+	plugin, ok := db.Config.Plugins[(&dbresolver.DBResolver{}).Name()]
+	if !ok {
+		t.Fatal("resolver not registered")
+	}
+
+	// The pools are the primary's and both replicas'
+	pools := 0
+	plugin.(*dbresolver.DBResolver).Call(func(pool gorm.ConnPool) error {
+		pools++
+		if stats := pool.(*sql.DB).Stats(); stats.MaxOpenConnections != 7 {
+			t.Errorf("MaxOpenConnections = %d, want 7", stats.MaxOpenConnections)
+		}
+		return nil
+	})
+	if pools != 3 {
+		t.Errorf("resolver has %d pools, want 3", pools)
+	}
+}
+
+func TestConfigurePool(t *testing.T) {
+	sqlDB, err := testutil.NewDB(t).DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	configurePool(sqlDB, config.DatabasePoolConfig{Max: 4, Idle: int(time.Second / time.Millisecond)})
+	if stats := sqlDB.Stats(); stats.MaxOpenConnections != 4 {
+		t.Errorf("MaxOpenConnections = %d, want 4", stats.MaxOpenConnections)
+	}
+}
+
+func TestTransactionRollsBackEveryRepo(t *testing.T) {
+	client := &DatabaseClient{DB: testutil.NewDB(t)}
+	ctx := context.Background()
+	vm := &entity.VirtualMachine{Name: "vm-a", Type: "lxc", UserID: 1}
+	if err := client.DB.Create(vm).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	errAbort := errors.New("abort")
+	err := client.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := database.NewVMLabelRepo(tx).Set(ctx, vm.Id, "env", "staging"); err != nil {
+			return err
+		}
+		if err := database.NewVirtualMachineRepo(tx).UpdateName(ctx, vm.Id, "vm-b"); err != nil {
+			return err
+		}
+		return errAbort
+	})
+	if !errors.Is(err, errAbort) {
+		t.Fatalf("Transaction() error = %v, want errAbort", err)
+	}
+
+	stored, err := database.NewVirtualMachineRepo(client.DB).Get(ctx, vm.Id)
+	if err != nil || stored.Name != "vm-a" {
+		t.Errorf("name = %q, %v, want the rename rolled back", stored.Name, err)
+	}
+	labels, err := database.NewVMLabelRepo(client.DB).ListByVM(ctx, vm.Id)
+	if err != nil || len(labels) != 0 {
+		t.Errorf("labels = %v, %v, want the label rolled back", labels, err)
+	}
+}
+
+func TestTransactionCommits(t *testing.T) {
+	client := &DatabaseClient{DB: testutil.NewDB(t)}
+	ctx := context.Background()
+	vm := &entity.VirtualMachine{Name: "vm-a", Type: "lxc", UserID: 1}
+	if err := client.DB.Create(vm).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	err := client.Transaction(ctx, func(tx *gorm.DB) error {
+		if err := database.NewVMLabelRepo(tx).Set(ctx, vm.Id, "env", "staging"); err != nil {
+			return err
+		}
+		return database.NewVirtualMachineRepo(tx).UpdateName(ctx, vm.Id, "vm-b")
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+
+	stored, err := database.NewVirtualMachineRepo(client.DB).Get(ctx, vm.Id)
+	if err != nil || stored.Name != "vm-b" {
+		t.Errorf("name = %q, %v, want vm-b", stored.Name, err)
+	}
+	labels, err := database.NewVMLabelRepo(client.DB).ListByVM(ctx, vm.Id)
+	if err != nil || labels["env"] != "staging" {
+		t.Errorf("labels = %v, %v, want env=staging", labels, err)
+	}
+}
+
+func TestMaskDSN(t *testing.T) {
+	cfg := config.DatabaseConfig{Username: "ra", Password: "p@ss:word", Host: "db", Port: 3306, Database: "ra"}
+
+	got := maskDSN(buildDataSourceName(cfg))
+	if strings.Contains(got, "p@ss:word") || !strings.HasPrefix(got, "ra:***@tcp(db:3306)/ra?") {
+		t.Errorf("maskDSN() = %q", got)
+	}
+	if got := maskDSN("not a dsn"); got != "not a dsn" {
+		t.Errorf("maskDSN() = %q, want input unchanged", got)
+	}
+}
+
+func TestMaskDSNError(t *testing.T) {
+	cfg := config.DatabaseConfig{Username: "ra", Password: "a", Host: "db", Port: 3306, Database: "ra"}
+	dsn := buildDataSourceName(cfg)
+
+	raw := fmt.Errorf("dial failed for %s: access denied", dsn)
+	masked := maskDSNError(raw, cfg)
+	want := "dial failed for ra:***@tcp(db:3306)/ra?charset=utf8mb4&parseTime=true: access denied"
+	if masked.Error() != want {
+		t.Errorf("maskDSNError() = %q, want %q", masked.Error(), want)
+	}
+	if errors.Is(masked, raw) || errors.Unwrap(masked) != nil {
+		t.Error("masked error exposes the raw error")
+	}
+
+	// The short password appears elsewhere in the message but only the DSN form is redacted
+	plain := errors.New("database ra is not available")
+	if got := maskDSNError(plain, cfg); got != plain {
+		t.Errorf("maskDSNError() = %q, want the error unchanged", got)
+	}
+}
+
+func TestDryRunMigrateReportsPendingChanges(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+		Logger:         logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	// An older schema: virtual_machine predates port and has a dropped field, vm_label is missing
+	if err := db.AutoMigrate(&entity.VirtualMachine{}, &entity.BuildRecord{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Migrator().DropColumn(&entity.VirtualMachine{}, "port"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Exec("ALTER TABLE virtual_machine ADD legacy_notes text").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	client := &DatabaseClient{DB: db}
+	pending, err := client.DryRunMigrate()
+	if err != nil {
+		t.Fatalf("DryRunMigrate() error = %v", err)
+	}
+
+	want := []string{
+		"ALTER TABLE virtual_machine ADD port integer",
+		"-- virtual_machine.legacy_notes is not in the model and will be left in place",
+		"CREATE TABLE vm_label",
+	}
+	if !slices.Equal(pending, want) {
+		t.Errorf("DryRunMigrate() = %q, want %q", pending, want)
+	}
+
+	// Nothing was applied
+	if db.Migrator().HasColumn(&entity.VirtualMachine{}, "port") || db.Migrator().HasTable(&entity.VMLabel{}) {
+		t.Error("DryRunMigrate() changed the schema")
+	}
+}
+
+func TestDryRunMigrateUpToDate(t *testing.T) {
+	client := &DatabaseClient{DB: testutil.NewDB(t)}
+
+	pending, err := client.DryRunMigrate()
+	if err != nil {
+		t.Fatalf("DryRunMigrate() error = %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("DryRunMigrate() on a migrated database = %q, want nothing", pending)
+	}
+}
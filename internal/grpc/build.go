@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	grpccore "github.com/cynxees/cynx-core/src/grpc"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/service/buildservice"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) BuildImage(ctx context.Context, req *pb.BuildImageRequest) (resp *pb.BuildResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.BuildService.BuildImage)
+}
+
+func (s *Server) GetBuild(ctx context.Context, req *pb.GetBuildRequest) (resp *pb.BuildResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.BuildService.GetBuild)
+}
+
+func (s *Server) StreamBuildLogs(req *pb.StreamBuildLogsRequest, stream pb.BuildService_StreamBuildLogsServer) error {
+	err := s.BuildService.StreamBuildLogs(stream.Context(), req.Id, func(line string) error {
+		return stream.Send(&pb.BuildLogLine{Line: line})
+	})
+	if errors.Is(err, buildservice.ErrBuildNotFound) {
+		return status.Errorf(codes.NotFound, "build %d not found", req.Id)
+	}
+	return err
+}
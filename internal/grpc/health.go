@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/cynxees/ra-server/internal/service/healthservice"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// healthServer implements the standard gRPC health protocol on top of the
+// health service. The empty service name reports overall health; component
+// names (database, disk, tools) report that component alone.
+type healthServer struct {
+	healthpb.UnimplementedHealthServer
+
+	HealthService *healthservice.Service
+}
+
+func (h *healthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	report := h.HealthService.Check(ctx)
+
+	healthy := report.Healthy
+	if req.GetService() != "" {
+		component, ok := report.Component(req.GetService())
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "unknown service %q", req.GetService())
+		}
+		healthy = component.Healthy
+	}
+
+	if !healthy {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
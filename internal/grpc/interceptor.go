@@ -0,0 +1,99 @@
+package grpc
+
+import (
+	"context"
+	"runtime/debug"
+	"strings"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	coreContext "github.com/cynxees/cynx-core/src/context"
+	coreResponse "github.com/cynxees/cynx-core/src/response"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/model/response"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// contextUnaryInterceptor copies the base request fields (request id, user id,
+// username, ...) into the context, where logger.FromContext picks them up.
+func contextUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if r, ok := req.(coreContext.RequestWithBase); ok {
+		ctx = coreContext.SetupContext(ctx, r)
+	}
+	return handler(ctx, req)
+}
+
+// recoveryUnaryInterceptor turns a panic in any handler into an internal error
+// instead of crashing the server, logging the stack with the method name. Like
+// grpccore.HandleGrpc, the error is reported in the BaseResponse of the method's
+// own response type; methods without one get an Internal status.
+func recoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(ctx).Error("Recovered from panic in ", info.FullMethod, ": ", r, "\n", string(debug.Stack()))
+			if internalResp, ok := internalErrorResponse(info.FullMethod); ok {
+				resp, err = internalResp, nil
+				return
+			}
+			resp, err = nil, status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(ctx, req)
+}
+
+// recoveryStreamInterceptor is recoveryUnaryInterceptor for streaming RPCs,
+// which have no single response to carry the error, so they end with an
+// Internal status
+func recoveryStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.FromContext(stream.Context()).Error("Recovered from panic in ", info.FullMethod, ": ", r, "\n", string(debug.Stack()))
+			err = status.Error(codes.Internal, "internal error")
+		}
+	}()
+	return handler(srv, stream)
+}
+
+// internalErrorResponse returns a new response message of fullMethod, e.g.
+// /ra.VirtualMachineService/GetVirtualMachine, with its base set to an internal
+// error. It reports false when the method is unknown or its response has no base.
+func internalErrorResponse(fullMethod string) (any, bool) {
+	serviceName, methodName, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok {
+		return nil, false
+	}
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, false
+	}
+	service, ok := descriptor.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, false
+	}
+	method := service.Methods().ByName(protoreflect.Name(methodName))
+	if method == nil {
+		return nil, false
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		return nil, false
+	}
+
+	message := messageType.New()
+	base := (&core.BaseResponse{}).ProtoReflect()
+	baseField := message.Descriptor().Fields().ByName("base")
+	if baseField == nil || baseField.Message() == nil || baseField.Message().FullName() != base.Descriptor().FullName() {
+		return nil, false
+	}
+	message.Set(baseField, protoreflect.ValueOfMessage(base))
+
+	resp, ok := message.Interface().(coreResponse.Generic)
+	if !ok {
+		return nil, false
+	}
+	response.ErrorInternal(resp)
+	return resp, true
+}
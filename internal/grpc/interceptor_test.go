@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestContextUnaryInterceptorSetsRequestFields(t *testing.T) {
+	var lines []string
+	previous := logger.SetWriter(func(ctx context.Context, _ logger.Level, line string) {
+		lines = append(lines, logger.RequestFields(ctx)+" "+line)
+	})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+
+	username := "bob"
+	req := &pb.GetVirtualMachineRequest{Base: &core.BaseRequest{RequestId: "req-3", Username: &username}}
+	_, err := contextUnaryInterceptor(context.Background(), req, nil, func(ctx context.Context, _ any) (any, error) {
+		logger.FromContext(ctx).Info("handled")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	want := "request_id=req-3 username=bob handled"
+	if len(lines) != 1 || lines[0] != want {
+		t.Fatalf("lines = %q, want [%q]", lines, want)
+	}
+}
+
+func silenceLogger(t *testing.T) {
+	t.Helper()
+	previous := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+}
+
+func panickingHandler(context.Context, any) (any, error) {
+	panic("nil map write")
+}
+
+func TestRecoveryUnaryInterceptorReturnsInternalError(t *testing.T) {
+	silenceLogger(t)
+
+	info := &grpc.UnaryServerInfo{FullMethod: pb.VirtualMachineService_GetVirtualMachine_FullMethodName}
+	resp, err := recoveryUnaryInterceptor(context.Background(), &pb.GetVirtualMachineRequest{}, info, panickingHandler)
+	if err != nil {
+		t.Fatalf("interceptor error = %v, want the error in the response", err)
+	}
+	vmResp, ok := resp.(*pb.VirtualMachineResponse)
+	if !ok {
+		t.Fatalf("response = %T, want *pb.VirtualMachineResponse", resp)
+	}
+	if vmResp.Base.GetCode() != "I-IE" || vmResp.Data != nil {
+		t.Errorf("response = %v, want an internal error without data", vmResp)
+	}
+}
+
+func TestRecoveryUnaryInterceptorWithoutBaseResponse(t *testing.T) {
+	silenceLogger(t)
+
+	info := &grpc.UnaryServerInfo{FullMethod: healthpb.Health_Check_FullMethodName}
+	resp, err := recoveryUnaryInterceptor(context.Background(), &healthpb.HealthCheckRequest{}, info, panickingHandler)
+	if resp != nil || status.Code(err) != codes.Internal {
+		t.Errorf("interceptor = %v, %v, want an Internal status", resp, err)
+	}
+}
+
+func TestRecoveryUnaryInterceptorPassesThrough(t *testing.T) {
+	want := &pb.VirtualMachineResponse{Base: &core.BaseResponse{Code: "00"}}
+	info := &grpc.UnaryServerInfo{FullMethod: pb.VirtualMachineService_GetVirtualMachine_FullMethodName}
+	resp, err := recoveryUnaryInterceptor(context.Background(), &pb.GetVirtualMachineRequest{}, info, func(context.Context, any) (any, error) {
+		return want, nil
+	})
+	if resp != want || err != nil {
+		t.Errorf("interceptor = %v, %v, want the handler's response", resp, err)
+	}
+}
+
+// contextStream is a grpc.ServerStream that only carries a context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context { return s.ctx }
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	silenceLogger(t)
+
+	info := &grpc.StreamServerInfo{FullMethod: pb.BuildService_StreamBuildLogs_FullMethodName, IsServerStream: true}
+	err := recoveryStreamInterceptor(nil, contextStream{ctx: context.Background()}, info, func(any, grpc.ServerStream) error {
+		panic("nil map write")
+	})
+	if status.Code(err) != codes.Internal {
+		t.Errorf("interceptor error = %v, want an Internal status", err)
+	}
+}
@@ -0,0 +1,20 @@
+package grpc
+
+import (
+	"context"
+
+	grpccore "github.com/cynxees/cynx-core/src/grpc"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+)
+
+func (s *Server) ListModes(ctx context.Context, req *pb.ListModesRequest) (resp *pb.ListModesResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.ModeService.ListModes)
+}
+
+func (s *Server) GetMode(ctx context.Context, req *pb.GetModeRequest) (resp *pb.ModeResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.ModeService.GetMode)
+}
+
+func (s *Server) CheckAnswer(ctx context.Context, req *pb.CheckAnswerRequest) (resp *pb.CheckAnswerResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.ModeService.CheckAnswer)
+}
@@ -2,19 +2,36 @@ package grpc
 
 import (
 	"context"
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/service/buildservice"
+	"github.com/cynxees/ra-server/internal/service/healthservice"
+	"github.com/cynxees/ra-server/internal/service/modeservice"
 	"github.com/cynxees/ra-server/internal/service/virtualmachineservice"
 	"net"
+	"sync"
 
 	"github.com/cynxees/cynx-core/src/logger"
 	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 )
 
 type Server struct {
 	pb.UnimplementedVirtualMachineServiceServer
+	pb.UnimplementedBuildServiceServer
+	pb.UnimplementedModeServiceServer
 
 	VirtualMachineService *virtualmachineservice.Service
+	HealthService         *healthservice.Service
+	BuildService          *buildservice.Service
+	ModeService           *modeservice.Service
+	// server is the running grpc.Server, kept so Stop can drain it
+	server *grpc.Server
+	Config config.GrpcConfig
+	mu     sync.Mutex
 }
 
 func (s *Server) Start(ctx context.Context, address string) error {
@@ -23,10 +40,87 @@ func (s *Server) Start(ctx context.Context, address string) error {
 		return err
 	}
 
-	server := grpc.NewServer()
+	opts, err := s.serverOptions()
+	if err != nil {
+		return err
+	}
+
+	server := grpc.NewServer(opts...)
 	pb.RegisterVirtualMachineServiceServer(server, s)
-	reflection.Register(server)
+	pb.RegisterBuildServiceServer(server, s)
+	pb.RegisterModeServiceServer(server, s)
+	healthpb.RegisterHealthServer(server, &healthServer{HealthService: s.HealthService})
+	if !s.Config.DisableReflection {
+		reflection.Register(server)
+	}
+
+	s.mu.Lock()
+	s.server = server
+	s.mu.Unlock()
 
 	logger.Info(ctx, "Starting gRPC server on ", address)
 	return server.Serve(lis)
 }
+
+// Stop lets in-flight RPCs finish, forcing the server closed once ctx is done.
+// It reports whether a running server was stopped.
+func (s *Server) Stop(ctx context.Context) bool {
+	s.mu.Lock()
+	server := s.server
+	s.server = nil
+	s.mu.Unlock()
+
+	if server == nil {
+		return false
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logger.Warn(ctx, "gRPC graceful stop timed out, closing remaining connections")
+		server.Stop()
+		<-stopped
+	}
+	return true
+}
+
+// serverOptions translates the gRPC config into server options
+func (s *Server) serverOptions() ([]grpc.ServerOption, error) {
+	interceptors := []grpc.UnaryServerInterceptor{contextUnaryInterceptor}
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if !s.Config.DisableRecovery {
+		interceptors = append(interceptors, recoveryUnaryInterceptor)
+		streamInterceptors = append(streamInterceptors, recoveryStreamInterceptor)
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(interceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	}
+
+	if s.Config.MaxRecvMsgBytes > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(s.Config.MaxRecvMsgBytes))
+	}
+	if s.Config.MaxSendMsgBytes > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(s.Config.MaxSendMsgBytes))
+	}
+	opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+		Time:              s.Config.KeepaliveTime(),
+		Timeout:           s.Config.KeepaliveTimeout(),
+		MaxConnectionIdle: s.Config.MaxConnectionIdle(),
+	}))
+
+	tlsConfig, err := s.Config.Tls.ServerTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	return opts, nil
+}
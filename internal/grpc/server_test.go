@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialTestServer serves the stock health service in memory with the options
+// cfg translates to and returns a client for it
+func dialTestServer(t *testing.T, cfg config.GrpcConfig) healthpb.HealthClient {
+	t.Helper()
+	opts, err := (&Server{Config: cfg}).serverOptions()
+	if err != nil {
+		t.Fatalf("serverOptions() error = %v", err)
+	}
+
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestServerRejectsOversizedMessages(t *testing.T) {
+	client := dialTestServer(t, config.GrpcConfig{MaxRecvMsgBytes: 1024})
+
+	// Within the limit the request reaches the handler
+	if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: strings.Repeat("x", 2048)})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("Check() error = %v, want ResourceExhausted", err)
+	}
+}
+
+func TestServerAcceptsMessagesUnderRaisedLimit(t *testing.T) {
+	client := dialTestServer(t, config.GrpcConfig{MaxRecvMsgBytes: 8 << 20})
+
+	// Larger than gRPC's 4 MiB default, so only the configured limit lets it through
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: strings.Repeat("x", 5<<20)})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("Check() error = %v, want NotFound for the unknown service", err)
+	}
+}
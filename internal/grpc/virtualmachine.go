@@ -10,3 +10,27 @@ import (
 func (s *Server) GetVirtualMachine(ctx context.Context, req *pb.GetVirtualMachineRequest) (resp *pb.VirtualMachineResponse, err error) {
 	return grpccore.HandleGrpc(ctx, req, resp, s.VirtualMachineService.GetVirtualMachine)
 }
+
+func (s *Server) ListVirtualMachines(ctx context.Context, req *pb.ListVirtualMachinesRequest) (resp *pb.ListVirtualMachinesResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.VirtualMachineService.ListVirtualMachines)
+}
+
+func (s *Server) RenameVirtualMachine(ctx context.Context, req *pb.RenameVirtualMachineRequest) (resp *pb.VirtualMachineResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.VirtualMachineService.RenameVirtualMachine)
+}
+
+func (s *Server) SetVirtualMachineLabel(ctx context.Context, req *pb.SetVirtualMachineLabelRequest) (resp *pb.VirtualMachineLabelsResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.VirtualMachineService.SetVirtualMachineLabel)
+}
+
+func (s *Server) RemoveVirtualMachineLabel(ctx context.Context, req *pb.RemoveVirtualMachineLabelRequest) (resp *pb.VirtualMachineLabelsResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.VirtualMachineService.RemoveVirtualMachineLabel)
+}
+
+func (s *Server) DeleteVirtualMachine(ctx context.Context, req *pb.DeleteVirtualMachineRequest) (resp *pb.VirtualMachineResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.VirtualMachineService.DeleteVirtualMachine)
+}
+
+func (s *Server) ReconcileVirtualMachines(ctx context.Context, req *pb.ReconcileVirtualMachinesRequest) (resp *pb.ReconcileVirtualMachinesResponse, err error) {
+	return grpccore.HandleGrpc(ctx, req, resp, s.VirtualMachineService.ReconcileVirtualMachines)
+}
@@ -0,0 +1,29 @@
+package helper
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// FreeDiskSpace returns the bytes available to unprivileged users on the
+// filesystem holding path. Missing paths are resolved to their nearest
+// existing parent, so it can be called before a build directory is created.
+func FreeDiskSpace(path string) (uint64, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	for {
+		if _, err := os.Stat(path); err == nil || filepath.Dir(path) == path {
+			break
+		}
+		path = filepath.Dir(path)
+	}
+
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// FollowFile emits each line of path as it is written, polling every interval
+// once it reaches the end of the file. After done reports true the remaining
+// lines are drained and FollowFile returns; it also stops when ctx is done or
+// emit fails.
+func FollowFile(ctx context.Context, path string, interval time.Duration, done func() (bool, error), emit func(line string) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	var partial strings.Builder
+	finished := false
+	for {
+		chunk, err := reader.ReadString('\n')
+		partial.WriteString(chunk)
+		if err == nil {
+			if err := emit(strings.TrimRight(partial.String(), "\r\n")); err != nil {
+				return err
+			}
+			partial.Reset()
+			continue
+		}
+		if !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		// At the end of the file: a finished writer will add nothing more
+		if finished {
+			if partial.Len() > 0 {
+				return emit(partial.String())
+			}
+			return nil
+		}
+		if finished, err = done(); err != nil {
+			return err
+		}
+		if finished {
+			continue
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
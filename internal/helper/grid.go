@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ParseGrid parses a square puzzle grid such as a Sudoku board. It accepts a
+// flat string of N*N cells, N newline-separated rows, or a JSON nested array.
+// '.', '0' and ' ' mark empty cells, which are returned as 0.
+func ParseGrid(input string) ([][]int, error) {
+	trimmed := strings.Trim(input, "\r\n")
+
+	var grid [][]int
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(trimmed), "["):
+		if err := json.Unmarshal([]byte(trimmed), &grid); err != nil {
+			return nil, fmt.Errorf("invalid grid array: %w", err)
+		}
+	case strings.Contains(trimmed, "\n"):
+		for i, line := range strings.Split(trimmed, "\n") {
+			row, err := parseGridRow(strings.TrimSuffix(line, "\r"))
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", i+1, err)
+			}
+			grid = append(grid, row)
+		}
+	default:
+		cells, err := parseGridRow(trimmed)
+		if err != nil {
+			return nil, err
+		}
+		side := int(math.Sqrt(float64(len(cells))))
+		if side == 0 || side*side != len(cells) {
+			return nil, fmt.Errorf("grid of %d cells is not square", len(cells))
+		}
+		for i := 0; i < len(cells); i += side {
+			grid = append(grid, cells[i:i+side])
+		}
+	}
+
+	side := len(grid)
+	if side == 0 {
+		return nil, fmt.Errorf("grid is empty")
+	}
+	for i, row := range grid {
+		if len(row) != side {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", i+1, len(row), side)
+		}
+		for j, value := range row {
+			if value < 0 || value > side {
+				return nil, fmt.Errorf("cell (%d,%d) value %d is out of range 0-%d", i+1, j+1, value, side)
+			}
+		}
+	}
+
+	return grid, nil
+}
+
+func parseGridRow(line string) ([]int, error) {
+	row := make([]int, 0, len(line))
+	for _, r := range line {
+		switch {
+		case r == '.' || r == '0' || r == ' ':
+			row = append(row, 0)
+		case r >= '1' && r <= '9':
+			row = append(row, int(r-'0'))
+		default:
+			return nil, fmt.Errorf("invalid character %q", r)
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,77 @@
+package helper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGridFormats(t *testing.T) {
+	want := [][]int{
+		{1, 0, 3, 0},
+		{0, 4, 0, 2},
+		{2, 0, 4, 0},
+		{0, 3, 0, 1},
+	}
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"flat string", "1.3..4.22.4..3.1"},
+		{"flat string with zeros", "1030040220400301"},
+		{"flat string with spaces", "1 3  4 22 4  3 1"},
+		{"rows", "1.3.\n.4.2\n2.4.\n.3.1"},
+		{"rows with CRLF and trailing newline", "1.3.\r\n.4.2\r\n2.4.\r\n.3.1\r\n"},
+		{"nested array", "[[1,0,3,0],[0,4,0,2],[2,0,4,0],[0,3,0,1]]"},
+		{"indented nested array", "  [\n [1,0,3,0],\n [0,4,0,2],\n [2,0,4,0],\n [0,3,0,1]\n]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGrid(tt.input)
+			if err != nil {
+				t.Fatalf("ParseGrid() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseGrid() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseGridSudoku(t *testing.T) {
+	board := "53..7....6..195....98....6.8...6...34..8.3..17...2...6.6....28....419..5....8..79"
+
+	grid, err := ParseGrid(board)
+	if err != nil {
+		t.Fatalf("ParseGrid() error = %v", err)
+	}
+	if len(grid) != 9 || grid[0][0] != 5 || grid[0][2] != 0 || grid[8][8] != 9 {
+		t.Errorf("ParseGrid() = %v", grid)
+	}
+}
+
+func TestParseGridRejectsMalformed(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"empty", "", "not square"},
+		{"not square", "12345", "not square"},
+		{"invalid character", "1x3..4.22.4..3.1", "invalid character"},
+		{"short row", "1.3.\n.4.\n2.4.\n.3.1", "row 2 has 3 cells"},
+		{"value out of range", "1.3..4.22.4..3.5", "out of range"},
+		{"ragged array", "[[1,0],[0]]", "row 2 has 1 cells"},
+		{"negative in array", "[[1,-1],[0,2]]", "out of range"},
+		{"broken array", "[[1,0],", "invalid grid array"},
+		{"empty array", "[]", "grid is empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseGrid(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseGrid(%q) error = %v, want %q", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package helper
 
+import (
+	"cmp"
+	"slices"
+)
+
 func PtrOrDefault[T any](p *T, def T) T {
 	if p != nil {
 		return *p
@@ -15,3 +20,36 @@ func Containsint32(slice []int32, item int32) bool {
 	}
 	return false
 }
+
+func Keys[K comparable, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+func Values[K comparable, V any](m map[K]V) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// SortedKeys returns the keys in ascending order, since map iteration order is random.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// GroupBy buckets the slice by key, keeping the original order within each bucket.
+func GroupBy[T any, K comparable](s []T, key func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for _, item := range s {
+		k := key(item)
+		groups[k] = append(groups[k], item)
+	}
+	return groups
+}
@@ -0,0 +1,83 @@
+package helper
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestKeysAndValues(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3}
+
+	keys := Keys(m)
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"a", "b", "c"}) {
+		t.Errorf("Keys() = %v", keys)
+	}
+
+	values := Values(m)
+	slices.Sort(values)
+	if !slices.Equal(values, []int{1, 2, 3}) {
+		t.Errorf("Values() = %v", values)
+	}
+}
+
+func TestKeysAndValuesOfEmptyMap(t *testing.T) {
+	var m map[string]int
+
+	if keys := Keys(m); keys == nil || len(keys) != 0 {
+		t.Errorf("Keys(nil) = %#v, want an empty slice", keys)
+	}
+	if values := Values(m); values == nil || len(values) != 0 {
+		t.Errorf("Values(nil) = %#v, want an empty slice", values)
+	}
+	if keys := SortedKeys(m); len(keys) != 0 {
+		t.Errorf("SortedKeys(nil) = %v, want none", keys)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	m := map[int32]string{42: "c", -1: "a", 7: "b", 100: "d"}
+
+	for range 5 {
+		if keys := SortedKeys(m); !slices.Equal(keys, []int32{-1, 7, 42, 100}) {
+			t.Fatalf("SortedKeys() = %v", keys)
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	type vm struct {
+		name   string
+		status string
+	}
+	vms := []vm{
+		{"web", "running"},
+		{"db", "inactive"},
+		{"cache", "running"},
+		{"queue", "provisioning"},
+		{"worker", "running"},
+	}
+
+	groups := GroupBy(vms, func(v vm) string { return v.status })
+
+	want := map[string][]vm{
+		"running":      {{"web", "running"}, {"cache", "running"}, {"worker", "running"}},
+		"inactive":     {{"db", "inactive"}},
+		"provisioning": {{"queue", "provisioning"}},
+	}
+	if len(groups) != len(want) {
+		t.Errorf("GroupBy() made %d buckets, want %d", len(groups), len(want))
+	}
+	for status, bucket := range want {
+		// Buckets keep the input order
+		if !slices.Equal(groups[status], bucket) {
+			t.Errorf("bucket %q = %v, want %v", status, groups[status], bucket)
+		}
+	}
+}
+
+func TestGroupByEmpty(t *testing.T) {
+	if groups := GroupBy([]int(nil), func(i int) int { return i }); len(groups) != 0 {
+		t.Errorf("GroupBy(nil) = %v, want no buckets", groups)
+	}
+}
@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Matcher decides whether a guess counts as the expected answer.
+type Matcher interface {
+	Match(guess, answer string) bool
+}
+
+// ExactMatcher requires the guess to equal the answer byte for byte.
+type ExactMatcher struct{}
+
+func (ExactMatcher) Match(guess, answer string) bool {
+	return guess == answer
+}
+
+// CaseInsensitiveMatcher compares answers after NormalizeAnswer.
+type CaseInsensitiveMatcher struct{}
+
+func (CaseInsensitiveMatcher) Match(guess, answer string) bool {
+	return NormalizeAnswer(guess) == NormalizeAnswer(answer)
+}
+
+// FuzzyMatcher accepts normalized guesses whose SimilarityRatio to the answer
+// is at least Threshold.
+type FuzzyMatcher struct {
+	Threshold float64
+}
+
+func (m FuzzyMatcher) Match(guess, answer string) bool {
+	return SimilarityRatio(NormalizeAnswer(guess), NormalizeAnswer(answer)) >= m.Threshold
+}
+
+// ParseMatcher builds a Matcher from its config form: "exact",
+// "case_insensitive" or "fuzzy:<threshold>" with a threshold in (0, 1].
+func ParseMatcher(spec string) (Matcher, error) {
+	name, arg, hasArg := strings.Cut(spec, ":")
+	switch name {
+	case "exact":
+		if !hasArg {
+			return ExactMatcher{}, nil
+		}
+	case "case_insensitive":
+		if !hasArg {
+			return CaseInsensitiveMatcher{}, nil
+		}
+	case "fuzzy":
+		threshold, err := strconv.ParseFloat(arg, 64)
+		if err != nil || threshold <= 0 || threshold > 1 {
+			return nil, fmt.Errorf("matcher %q needs a threshold in (0, 1]", spec)
+		}
+		return FuzzyMatcher{Threshold: threshold}, nil
+	}
+	return nil, fmt.Errorf("unknown matcher %q", spec)
+}
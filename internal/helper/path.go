@@ -0,0 +1,20 @@
+package helper
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SafeJoin joins rel onto base and rejects the result if, after cleaning, it
+// escapes base. Absolute rel paths are treated as relative to base.
+func SafeJoin(base, rel string) (string, error) {
+	base = filepath.Clean(base)
+	joined := filepath.Join(base, rel)
+
+	relToBase, err := filepath.Rel(base, joined)
+	if err != nil || relToBase == ".." || strings.HasPrefix(relToBase, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q escapes %s", rel, base)
+	}
+	return joined, nil
+}
@@ -0,0 +1,36 @@
+package helper
+
+import "testing"
+
+func TestSafeJoin(t *testing.T) {
+	tests := []struct {
+		name    string
+		rel     string
+		want    string
+		wantErr bool
+	}{
+		{name: "normal", rel: "containers/ubuntu-base", want: "/srv/build/containers/ubuntu-base"},
+		{name: "cleaned", rel: "containers/../logs/build.log", want: "/srv/build/logs/build.log"},
+		{name: "absolute stays inside", rel: "/etc/passwd", want: "/srv/build/etc/passwd"},
+		{name: "base itself", rel: ".", want: "/srv/build"},
+		{name: "traversal", rel: "../../etc", wantErr: true},
+		{name: "parent", rel: "..", wantErr: true},
+		{name: "nested traversal", rel: "containers/../../../etc/shadow", wantErr: true},
+		{name: "dotted name", rel: "..config", want: "/srv/build/..config"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SafeJoin("/srv/build/", tt.rel)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("SafeJoin(%q) = %q, want an error", tt.rel, got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("SafeJoin(%q) = %q, %v, want %q", tt.rel, got, err, tt.want)
+			}
+		})
+	}
+}
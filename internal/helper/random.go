@@ -1,10 +1,61 @@
 package helper
 
 import (
+	"context"
 	"math/rand"
+	"sync"
 	"time"
 )
 
+var (
+	randMu sync.Mutex
+	// defaultRand backs the helpers below when no seed is given
+	defaultRand = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// SetRandSource replaces the source behind the unseeded random helpers, so
+// tests can make them deterministic. Production keeps the time-seeded default.
+func SetRandSource(src rand.Source) {
+	randMu.Lock()
+	defer randMu.Unlock()
+	defaultRand = rand.New(src)
+}
+
+// randIntn draws from defaultRand, which is not safe for concurrent use on its own
+func randIntn(n int) int {
+	randMu.Lock()
+	defer randMu.Unlock()
+	return defaultRand.Intn(n)
+}
+
+// randContextKey keys the request rand stored by WithRand
+type randContextKey struct{}
+
+// DailySeed derives a seed from the UTC calendar day of t, e.g. 20261015, so
+// every request on the same day draws the same sequence
+func DailySeed(t time.Time) int64 {
+	year, month, day := t.UTC().Date()
+	return int64(year*10000 + int(month)*100 + day)
+}
+
+// WithRand returns a context carrying a rand seeded with seed. The rand is not
+// safe for concurrent use, so only the request owning ctx should draw from it.
+func WithRand(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, randContextKey{}, rand.New(rand.NewSource(seed)))
+}
+
+// RandFromContext returns the rand stored by WithRand, or a freshly seeded
+// one drawn from the default source when ctx carries none
+func RandFromContext(ctx context.Context) *rand.Rand {
+	if r, ok := ctx.Value(randContextKey{}).(*rand.Rand); ok {
+		return r
+	}
+
+	randMu.Lock()
+	defer randMu.Unlock()
+	return rand.New(rand.NewSource(defaultRand.Int63()))
+}
+
 func GenerateRandomNumber(length int) int {
 	if length <= 0 {
 		return 0
@@ -17,7 +68,7 @@ func GenerateRandomNumber(length int) int {
 		maximum = maximum*10 + 9
 	}
 
-	return minimum + rand.Intn(maximum-minimum+1)
+	return minimum + randIntn(maximum-minimum+1)
 }
 
 func GenerateRandomNumberInRange(min, max, seed int) int {
@@ -25,12 +76,9 @@ func GenerateRandomNumberInRange(min, max, seed int) int {
 		return min
 	}
 
-	var r *rand.Rand
 	if seed > 0 {
-		r = rand.New(rand.NewSource(int64(seed)))
-	} else {
-		r = rand.New(rand.NewSource(time.Now().UnixNano()))
+		r := rand.New(rand.NewSource(int64(seed)))
+		return min + r.Intn(max-min+1)
 	}
-
-	return min + r.Intn(max-min+1)
+	return min + randIntn(max-min+1)
 }
@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+// freezeRand makes the unseeded helpers draw from seed until the test ends
+func freezeRand(t *testing.T, seed int64) {
+	t.Helper()
+	SetRandSource(rand.NewSource(seed))
+	t.Cleanup(func() { SetRandSource(rand.NewSource(time.Now().UnixNano())) })
+}
+
+// draw returns a few values from the unseeded helpers
+func draw() []int {
+	return []int{
+		GenerateRandomNumber(6),
+		GenerateRandomNumberInRange(1, 1000, 0),
+		GenerateRandomNumber(3),
+	}
+}
+
+func TestSetRandSourceMakesHelpersDeterministic(t *testing.T) {
+	freezeRand(t, 42)
+	first := draw()
+
+	freezeRand(t, 42)
+	if second := draw(); !slices.Equal(first, second) {
+		t.Errorf("same source drew %v then %v", first, second)
+	}
+
+	freezeRand(t, 43)
+	if other := draw(); slices.Equal(first, other) {
+		t.Errorf("different sources both drew %v", first)
+	}
+}
+
+func TestRandomHelpersStayInRange(t *testing.T) {
+	freezeRand(t, 1)
+	for range 100 {
+		if n := GenerateRandomNumber(3); n < 100 || n > 999 {
+			t.Fatalf("GenerateRandomNumber(3) = %d", n)
+		}
+		if n := GenerateRandomNumberInRange(5, 10, 0); n < 5 || n > 10 {
+			t.Fatalf("GenerateRandomNumberInRange(5, 10) = %d", n)
+		}
+	}
+	if n := GenerateRandomNumberInRange(5, 10, 7); n != GenerateRandomNumberInRange(5, 10, 7) {
+		t.Error("an explicit seed drew different numbers")
+	}
+}
+
+// generatePuzzle stands in for an engine that draws its layout from the request rand
+func generatePuzzle(ctx context.Context) []int {
+	return RandFromContext(ctx).Perm(9)
+}
+
+func TestRandFromContextIsDeterministicPerSeed(t *testing.T) {
+	seed := DailySeed(time.Date(2026, 10, 15, 23, 0, 0, 0, time.UTC))
+
+	first := generatePuzzle(WithRand(context.Background(), seed))
+	second := generatePuzzle(WithRand(context.Background(), seed))
+	if !slices.Equal(first, second) {
+		t.Errorf("same seed generated %v then %v", first, second)
+	}
+
+	other := generatePuzzle(WithRand(context.Background(), seed+1))
+	if slices.Equal(first, other) {
+		t.Errorf("different seeds both generated %v", first)
+	}
+}
+
+func TestRandFromContextFallsBackToDefaultSource(t *testing.T) {
+	freezeRand(t, 42)
+	first := generatePuzzle(context.Background())
+
+	freezeRand(t, 42)
+	if second := generatePuzzle(context.Background()); !slices.Equal(first, second) {
+		t.Errorf("fallback ignored the default source: %v then %v", first, second)
+	}
+}
+
+func TestDailySeed(t *testing.T) {
+	// The same UTC day gives the same seed whatever the local zone
+	zone := time.FixedZone("UTC+9", 9*60*60)
+	morning := time.Date(2026, 10, 16, 8, 0, 0, 0, zone)
+	evening := time.Date(2026, 10, 15, 20, 0, 0, 0, time.UTC)
+
+	if DailySeed(morning) != 20261015 || DailySeed(evening) != 20261015 {
+		t.Errorf("DailySeed() = %d, %d, want 20261015", DailySeed(morning), DailySeed(evening))
+	}
+}
@@ -0,0 +1,64 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryableError marks an error as transient. Retry only retries errors
+// carrying this marker; anything else is treated as permanent.
+type RetryableError struct {
+	Err error
+}
+
+func (e *RetryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
+
+func Retryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &RetryableError{Err: err}
+}
+
+// Retry calls fn up to attempts times, doubling the wait after each retryable
+// failure and adding up to 50% jitter. It stops early on success, on a
+// permanent error, or when ctx is done, returning the last error seen.
+// attempts below 1 still make a single attempt.
+func Retry(ctx context.Context, attempts int, backoff time.Duration, fn func() error) error {
+	attempts = max(attempts, 1)
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+
+		var retryable *RetryableError
+		if !errors.As(err, &retryable) || attempt == attempts {
+			return err
+		}
+
+		wait := backoff
+		if backoff > 0 {
+			wait += time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.Join(err, ctx.Err())
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+	return err
+}
@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	errTransient := errors.New("transient")
+	errPermanent := errors.New("permanent")
+
+	tests := []struct {
+		wantErr   error
+		name      string
+		failures  []error
+		attempts  int
+		wantCalls int
+	}{
+		{name: "success first try", attempts: 3, wantCalls: 1},
+		{name: "success after failures", attempts: 3, failures: []error{Retryable(errTransient), Retryable(errTransient)}, wantCalls: 3},
+		{name: "retryable until exhausted", attempts: 2, failures: []error{Retryable(errTransient), Retryable(errTransient), Retryable(errTransient)}, wantCalls: 2, wantErr: errTransient},
+		{name: "permanent error short-circuits", attempts: 3, failures: []error{errPermanent}, wantCalls: 1, wantErr: errPermanent},
+		{name: "zero attempts still tries once", attempts: 0, failures: []error{errPermanent}, wantCalls: 1, wantErr: errPermanent},
+		{name: "negative attempts still tries once", attempts: -2, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := Retry(context.Background(), tt.attempts, time.Millisecond, func() error {
+				calls++
+				if calls <= len(tt.failures) {
+					return tt.failures[calls-1]
+				}
+				return nil
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRetryStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	errTransient := errors.New("transient")
+
+	calls := 0
+	err := Retry(ctx, 5, time.Hour, func() error {
+		calls++
+		cancel()
+		return Retryable(errTransient)
+	})
+
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, errTransient) {
+		t.Errorf("err = %v, want the last error joined with context.Canceled", err)
+	}
+}
@@ -0,0 +1,75 @@
+package helper
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"kitten", "sitting", 3},
+		{"sitting", "kitten", 3},
+		{"flaw", "lawn", 2},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"", "", 0},
+		{"same", "same", 0},
+		// Accented letters are single runes, so each substitution costs one
+		{"café", "cafe", 1},
+		{"naïve", "naive", 1},
+		{"résumé", "resume", 2},
+		{"日本語", "日本", 1},
+	}
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSimilarityRatio(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"", "", 1},
+		{"same", "same", 1},
+		{"abc", "xyz", 0},
+		{"café", "cafe", 0.75},
+		{"kitten", "sitting", 1 - 3.0/7},
+	}
+	for _, tt := range tests {
+		if got := SimilarityRatio(tt.a, tt.b); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("SimilarityRatio(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeAnswer(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Café ", "cafe"},
+		{"  Hello   World\t", "hello world"},
+		{"Crème\nBrûlée", "creme brulee"},
+		{"ÅNGSTRÖM", "angstrom"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := NormalizeAnswer(tt.input); got != tt.want {
+			t.Errorf("NormalizeAnswer(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	if NormalizeAnswer("Café ") != NormalizeAnswer("cafe") {
+		t.Error(`"Café " and "cafe" do not match after normalization`)
+	}
+	// A decomposed é (e + combining acute) normalizes the same as the precomposed one
+	if NormalizeAnswer("cafe\u0301") != NormalizeAnswer("café") {
+		t.Error("decomposed and precomposed accents normalize differently")
+	}
+}
@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	coreContext "github.com/cynxees/cynx-core/src/context"
+	coreLogger "github.com/cynxees/cynx-core/src/logger"
+)
+
+// Level is the severity a line is written at
+type Level string
+
+const (
+	LevelDebug Level = "DEBUG"
+	LevelInfo  Level = "INFO"
+	LevelWarn  Level = "WARN"
+	LevelError Level = "ERROR"
+)
+
+// Writer sends a finished line at level
+type Writer func(ctx context.Context, level Level, line string)
+
+// coreWriter writes through the cynx-core logger, which also ships the line to Elastic
+func coreWriter(ctx context.Context, level Level, line string) {
+	switch level {
+	case LevelDebug:
+		coreLogger.Debug(ctx, line)
+	case LevelWarn:
+		coreLogger.Warn(ctx, line)
+	case LevelError:
+		coreLogger.Error(ctx, line)
+	default:
+		coreLogger.Info(ctx, line)
+	}
+}
+
+// StdoutWriter writes lines to stdout through the standard library logger, for
+// entry points that run without the cynx-core logger being initialized. Unlike
+// the cynx-core logger it has nowhere structured to put the request fields, so
+// it prefixes them to the line.
+func StdoutWriter(ctx context.Context, level Level, line string) {
+	if fields := RequestFields(ctx); fields != "" {
+		line = "[" + fields + "] " + line
+	}
+	log.Printf("%s %s", level, line)
+}
+
+var (
+	writerMu sync.RWMutex
+	writer   Writer = coreWriter
+)
+
+// SetWriter replaces where every Logger writes and returns the previous
+// writer, so tests can capture lines and restore it
+func SetWriter(w Writer) Writer {
+	writerMu.Lock()
+	defer writerMu.Unlock()
+	previous := writer
+	writer = w
+	return previous
+}
+
+func currentWriter() Writer {
+	writerMu.RLock()
+	defer writerMu.RUnlock()
+	return writer
+}
+
+// Logger writes lines with the context it was created from, which the writer
+// reads the request fields from
+type Logger struct {
+	ctx context.Context
+}
+
+// FromContext returns a Logger writing with ctx. The cynx-core logger takes the
+// request id, user id and username from ctx itself, so lines are not prefixed
+// with them.
+func FromContext(ctx context.Context) *Logger {
+	return &Logger{ctx: ctx}
+}
+
+// RequestFields formats the request fields set by coreContext.SetupContext,
+// e.g. "request_id=req-1 user_id=7", for writers that need them in the line
+func RequestFields(ctx context.Context) string {
+	var fields []string
+	if requestID := coreContext.GetKeyOrEmpty(ctx, coreContext.KeyRequestId); requestID != "" {
+		fields = append(fields, "request_id="+requestID)
+	}
+	if userID := coreContext.GetUserId(ctx); userID != nil {
+		fields = append(fields, fmt.Sprintf("user_id=%d", *userID))
+	}
+	if username := coreContext.GetKeyOrEmpty(ctx, coreContext.KeyUsername); username != "" {
+		fields = append(fields, "username="+username)
+	}
+	return strings.Join(fields, " ")
+}
+
+func (l *Logger) write(level Level, args []interface{}) {
+	currentWriter()(l.ctx, level, fmt.Sprint(args...))
+}
+
+func (l *Logger) Debug(args ...interface{}) { l.write(LevelDebug, args) }
+
+func (l *Logger) Info(args ...interface{}) { l.write(LevelInfo, args) }
+
+func (l *Logger) Warn(args ...interface{}) { l.write(LevelWarn, args) }
+
+func (l *Logger) Error(args ...interface{}) { l.write(LevelError, args) }
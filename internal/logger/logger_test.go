@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+	"testing"
+
+	coreContext "github.com/cynxees/cynx-core/src/context"
+)
+
+type capturedLine struct {
+	level Level
+	line  string
+}
+
+func captureLines(t *testing.T) *[]capturedLine {
+	t.Helper()
+	var lines []capturedLine
+	previous := SetWriter(func(_ context.Context, level Level, line string) {
+		lines = append(lines, capturedLine{level: level, line: line})
+	})
+	t.Cleanup(func() { SetWriter(previous) })
+	return &lines
+}
+
+func TestFromContextLeavesRequestFieldsToTheWriter(t *testing.T) {
+	var got context.Context
+	var lines []capturedLine
+	previous := SetWriter(func(ctx context.Context, level Level, line string) {
+		got = ctx
+		lines = append(lines, capturedLine{level: level, line: line})
+	})
+	t.Cleanup(func() { SetWriter(previous) })
+
+	ctx := coreContext.SetKey(context.Background(), coreContext.KeyRequestId, "req-1")
+	ctx = coreContext.SetUserId(ctx, 7)
+	ctx = coreContext.SetKey(ctx, coreContext.KeyUsername, "alice")
+
+	FromContext(ctx).Warn("deleting ", 3)
+
+	// The cynx-core logger adds the fields from ctx, so the line must not repeat them
+	want := capturedLine{level: LevelWarn, line: "deleting 3"}
+	if len(lines) != 1 || lines[0] != want {
+		t.Fatalf("lines = %+v, want [%+v]", lines, want)
+	}
+	if fields := RequestFields(got); fields != "request_id=req-1 user_id=7 username=alice" {
+		t.Errorf("writer context fields = %q", fields)
+	}
+}
+
+func TestStdoutWriterPrefixesRequestFields(t *testing.T) {
+	var out strings.Builder
+	log.SetOutput(&out)
+	log.SetFlags(0)
+	t.Cleanup(func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	})
+
+	ctx := coreContext.SetKey(context.Background(), coreContext.KeyRequestId, "req-4")
+	StdoutWriter(ctx, LevelInfo, "building")
+	StdoutWriter(context.Background(), LevelInfo, "plain")
+
+	want := "INFO [request_id=req-4] building\nINFO plain\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestFromContextWithoutFields(t *testing.T) {
+	lines := captureLines(t)
+
+	FromContext(context.Background()).Info("plain")
+
+	want := capturedLine{level: LevelInfo, line: "plain"}
+	if len(*lines) != 1 || (*lines)[0] != want {
+		t.Fatalf("lines = %+v, want [%+v]", *lines, want)
+	}
+}
+
+func TestFromContextKeepsDerivedContext(t *testing.T) {
+	captureLines(t)
+
+	type key struct{}
+	ctx := coreContext.SetKey(context.Background(), coreContext.KeyRequestId, "req-2")
+	derived := context.WithValue(ctx, key{}, "value")
+
+	var got context.Context
+	SetWriter(func(ctx context.Context, _ Level, _ string) { got = ctx })
+	FromContext(derived).Info("line")
+
+	if got == nil || got.Value(key{}) != "value" {
+		t.Fatal("writer did not receive the derived context")
+	}
+}
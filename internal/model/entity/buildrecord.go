@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/cynxees/cynx-core/src/entity"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type BuildRecord struct {
+	entity.EssentialEntity
+	StartedAt    *time.Time           `gorm:"column:started_at" json:"started_at"`
+	FinishedAt   *time.Time           `gorm:"column:finished_at" json:"finished_at"`
+	Kind         constant.BuildKind   `gorm:"column:kind;not null" json:"kind"`
+	Base         string               `gorm:"column:base" json:"base"`
+	SpecHash     string               `gorm:"column:spec_hash" json:"spec_hash"`
+	Status       constant.BuildStatus `gorm:"column:status;default:'PENDING'" json:"status"`
+	ArtifactPath string               `gorm:"column:artifact_path" json:"artifact_path"`
+	Checksum     string               `gorm:"column:checksum" json:"checksum"`
+	LogPath      string               `gorm:"column:log_path" json:"log_path"`
+	Error        string               `gorm:"column:error;type:text" json:"error"`
+}
+
+func (b BuildRecord) Response() *pb.Build {
+	build := &pb.Build{
+		Id:           b.Id,
+		Kind:         string(b.Kind),
+		Base:         b.Base,
+		Status:       string(b.Status),
+		ArtifactPath: b.ArtifactPath,
+		Checksum:     b.Checksum,
+		LogPath:      b.LogPath,
+		Error:        b.Error,
+	}
+	if b.StartedAt != nil {
+		build.StartedAt = timestamppb.New(*b.StartedAt)
+	}
+	if b.FinishedAt != nil {
+		build.FinishedAt = timestamppb.New(*b.FinishedAt)
+	}
+	return build
+}
@@ -0,0 +1,10 @@
+package entity
+
+// MigrationModels lists every entity managed by AutoMigrate
+func MigrationModels() []interface{} {
+	return []interface{}{
+		&VirtualMachine{},
+		&BuildRecord{},
+		&VMLabel{},
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+// modeEnumPrefix is prepended to a ModeType to get its proto enum name, e.g. MODE_WORDLE
+const modeEnumPrefix = "MODE_"
+
+// ModeFromProto converts a proto Mode into its ModeType, rejecting
+// MODE_UNSPECIFIED and values this server does not know.
+func ModeFromProto(mode pb.Mode) (constant.ModeType, error) {
+	name, ok := pb.Mode_name[int32(mode)]
+	if !ok || mode == pb.Mode_MODE_UNSPECIFIED {
+		return "", fmt.Errorf("unknown mode %d", mode)
+	}
+
+	modeType := constant.ModeType(strings.TrimPrefix(name, modeEnumPrefix))
+	if !modeType.IsValid() {
+		return "", fmt.Errorf("mode %s has no matching mode type", name)
+	}
+	return modeType, nil
+}
+
+// ModeToProto converts a ModeType into its proto Mode.
+func ModeToProto(modeType constant.ModeType) (pb.Mode, error) {
+	if !modeType.IsValid() {
+		return pb.Mode_MODE_UNSPECIFIED, fmt.Errorf("invalid mode type %q", string(modeType))
+	}
+
+	value, ok := pb.Mode_value[modeEnumPrefix+string(modeType)]
+	if !ok {
+		return pb.Mode_MODE_UNSPECIFIED, fmt.Errorf("mode type %s has no proto mode", modeType)
+	}
+	return pb.Mode(value), nil
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+func TestModeProtoRoundTrip(t *testing.T) {
+	for _, modeType := range constant.ModeTypes {
+		mode, err := ModeToProto(modeType)
+		if err != nil {
+			t.Errorf("ModeToProto(%s) error = %v", modeType, err)
+			continue
+		}
+		got, err := ModeFromProto(mode)
+		if err != nil || got != modeType {
+			t.Errorf("ModeFromProto(%s) = %s, %v, want %s", mode, got, err, modeType)
+		}
+	}
+}
+
+func TestModeFromProtoRejects(t *testing.T) {
+	for _, mode := range []pb.Mode{pb.Mode_MODE_UNSPECIFIED, pb.Mode(9999), pb.Mode(-1)} {
+		if got, err := ModeFromProto(mode); err == nil {
+			t.Errorf("ModeFromProto(%d) = %s, want an error", mode, got)
+		}
+	}
+}
+
+func TestModeToProtoRejects(t *testing.T) {
+	for _, modeType := range []constant.ModeType{"", "NOT_A_MODE", "wordle"} {
+		if got, err := ModeToProto(modeType); err == nil {
+			t.Errorf("ModeToProto(%q) = %s, want an error", modeType, got)
+		}
+	}
+}
+
+func TestEveryProtoModeHasModeType(t *testing.T) {
+	for value, name := range pb.Mode_name {
+		if pb.Mode(value) == pb.Mode_MODE_UNSPECIFIED {
+			continue
+		}
+		if _, err := ModeFromProto(pb.Mode(value)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package entity
+
+import (
+	"github.com/cynxees/cynx-core/src/entity"
+)
+
+// VMLabel is a key/value label on a virtual machine; keys are unique per VM
+type VMLabel struct {
+	entity.EssentialEntity
+	Key   string `gorm:"column:label_key;size:63;not null;uniqueIndex:idx_vm_label_vm_key" json:"key"`
+	Value string `gorm:"column:label_value;size:63" json:"value"`
+	VMID  int32  `gorm:"column:vm_id;not null;uniqueIndex:idx_vm_label_vm_key;index" json:"vm_id"`
+}
@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// VMResources is the sizing stored as JSON in VirtualMachine.Resources
+type VMResources struct {
+	CPUs     int32 `json:"cpus"`
+	MemoryMB int32 `json:"memory_mb"`
+	DiskGB   int32 `json:"disk_gb"`
+}
+
+// VMResourceBounds are the inclusive limits VMResources.Validate enforces
+type VMResourceBounds struct {
+	MinCPUs     int32
+	MaxCPUs     int32
+	MinMemoryMB int32
+	MaxMemoryMB int32
+	MinDiskGB   int32
+	MaxDiskGB   int32
+}
+
+// DefaultVMResourceBounds apply until SetVMResourceBounds overrides them
+var DefaultVMResourceBounds = VMResourceBounds{
+	MinCPUs:     1,
+	MaxCPUs:     32,
+	MinMemoryMB: 256,
+	MaxMemoryMB: 64 * 1024,
+	MinDiskGB:   1,
+	MaxDiskGB:   1024,
+}
+
+var (
+	vmResourceBoundsMu sync.RWMutex
+	vmResourceBounds   = DefaultVMResourceBounds
+)
+
+// SetVMResourceBounds replaces the bounds used by Validate; zero fields keep
+// their default.
+func SetVMResourceBounds(bounds VMResourceBounds) {
+	defaults := DefaultVMResourceBounds
+	bounds.MinCPUs = orDefault(bounds.MinCPUs, defaults.MinCPUs)
+	bounds.MaxCPUs = orDefault(bounds.MaxCPUs, defaults.MaxCPUs)
+	bounds.MinMemoryMB = orDefault(bounds.MinMemoryMB, defaults.MinMemoryMB)
+	bounds.MaxMemoryMB = orDefault(bounds.MaxMemoryMB, defaults.MaxMemoryMB)
+	bounds.MinDiskGB = orDefault(bounds.MinDiskGB, defaults.MinDiskGB)
+	bounds.MaxDiskGB = orDefault(bounds.MaxDiskGB, defaults.MaxDiskGB)
+
+	vmResourceBoundsMu.Lock()
+	defer vmResourceBoundsMu.Unlock()
+	vmResourceBounds = bounds
+}
+
+func orDefault(value, fallback int32) int32 {
+	if value == 0 {
+		return fallback
+	}
+	return value
+}
+
+// CurrentVMResourceBounds returns the bounds Validate enforces.
+func CurrentVMResourceBounds() VMResourceBounds {
+	vmResourceBoundsMu.RLock()
+	defer vmResourceBoundsMu.RUnlock()
+	return vmResourceBounds
+}
+
+// Validate rejects resources outside the configured bounds, so nonsense such
+// as 0 CPUs never reaches QEMU or LXC.
+func (r VMResources) Validate() error {
+	return r.ValidateWithin(CurrentVMResourceBounds())
+}
+
+// ValidateWithin checks r against explicit bounds.
+func (r VMResources) ValidateWithin(bounds VMResourceBounds) error {
+	if err := checkBounds("cpus", r.CPUs, bounds.MinCPUs, bounds.MaxCPUs); err != nil {
+		return err
+	}
+	if err := checkBounds("memory_mb", r.MemoryMB, bounds.MinMemoryMB, bounds.MaxMemoryMB); err != nil {
+		return err
+	}
+	return checkBounds("disk_gb", r.DiskGB, bounds.MinDiskGB, bounds.MaxDiskGB)
+}
+
+func checkBounds(name string, value, lo, hi int32) error {
+	if value < lo || value > hi {
+		return fmt.Errorf("%s must be between %d and %d, got %d", name, lo, hi, value)
+	}
+	return nil
+}
+
+// ParseResources decodes the VM's Resources column
+func (vm VirtualMachine) ParseResources() (VMResources, error) {
+	var res VMResources
+	if vm.Resources == "" {
+		return res, fmt.Errorf("virtual machine %d has no resources", vm.Id)
+	}
+	if err := json.Unmarshal([]byte(vm.Resources), &res); err != nil {
+		return res, fmt.Errorf("invalid resources for virtual machine %d: %w", vm.Id, err)
+	}
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVMResourcesValidate(t *testing.T) {
+	valid := VMResources{CPUs: 2, MemoryMB: 2048, DiskGB: 20}
+	tests := []struct {
+		name      string
+		wantField string
+		resources VMResources
+	}{
+		{"valid", "", valid},
+		{"lower bounds", "", VMResources{CPUs: 1, MemoryMB: 256, DiskGB: 1}},
+		{"upper bounds", "", VMResources{CPUs: 32, MemoryMB: 64 * 1024, DiskGB: 1024}},
+		{"zero cpus", "cpus", VMResources{CPUs: 0, MemoryMB: 2048, DiskGB: 20}},
+		{"too many cpus", "cpus", VMResources{CPUs: 33, MemoryMB: 2048, DiskGB: 20}},
+		{"too little memory", "memory_mb", VMResources{CPUs: 2, MemoryMB: 255, DiskGB: 20}},
+		{"too much memory", "memory_mb", VMResources{CPUs: 2, MemoryMB: 64*1024 + 1, DiskGB: 20}},
+		{"zero disk", "disk_gb", VMResources{CPUs: 2, MemoryMB: 2048, DiskGB: 0}},
+		{"too much disk", "disk_gb", VMResources{CPUs: 2, MemoryMB: 2048, DiskGB: 1025}},
+		{"negative cpus", "cpus", VMResources{CPUs: -1, MemoryMB: 2048, DiskGB: 20}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.resources.ValidateWithin(DefaultVMResourceBounds)
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("ValidateWithin: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantField+" ") {
+				t.Errorf("ValidateWithin error = %v, want one about %s", err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestSetVMResourceBounds(t *testing.T) {
+	t.Cleanup(func() { SetVMResourceBounds(DefaultVMResourceBounds) })
+
+	SetVMResourceBounds(VMResourceBounds{MaxCPUs: 4, MinMemoryMB: 512})
+
+	bounds := CurrentVMResourceBounds()
+	want := DefaultVMResourceBounds
+	want.MaxCPUs = 4
+	want.MinMemoryMB = 512
+	if bounds != want {
+		t.Errorf("CurrentVMResourceBounds() = %+v, want %+v", bounds, want)
+	}
+
+	if err := (VMResources{CPUs: 5, MemoryMB: 2048, DiskGB: 20}).Validate(); err == nil {
+		t.Error("Validate accepted 5 cpus above the configured maximum of 4")
+	}
+	if err := (VMResources{CPUs: 2, MemoryMB: 256, DiskGB: 20}).Validate(); err == nil {
+		t.Error("Validate accepted 256 MB below the configured minimum of 512")
+	}
+	if err := (VMResources{CPUs: 4, MemoryMB: 512, DiskGB: 1024}).Validate(); err != nil {
+		t.Errorf("Validate rejected resources on the configured bounds: %v", err)
+	}
+}
+
+func TestParseResources(t *testing.T) {
+	res, err := VirtualMachine{Resources: `{"cpus":2,"memory_mb":1024,"disk_gb":10}`}.ParseResources()
+	if err != nil {
+		t.Fatalf("ParseResources: %v", err)
+	}
+	if res != (VMResources{CPUs: 2, MemoryMB: 1024, DiskGB: 10}) {
+		t.Errorf("ParseResources() = %+v", res)
+	}
+
+	for _, raw := range []string{"", "not json"} {
+		if _, err := (VirtualMachine{Resources: raw}).ParseResources(); err == nil {
+			t.Errorf("ParseResources(%q) returned no error", raw)
+		}
+	}
+}
@@ -15,6 +15,7 @@ const (
 	codeNotFound           Code = "NF"
 	codeInvalidCredentials Code = "IC"
 	codeAlreadyExists      Code = "AE"
+	codeConflict           Code = "CF"
 
 	// Internal
 	codeInternalError Code = "I-IE"
@@ -28,6 +29,8 @@ const (
 	codeDbAnswerCategory Code = "DB-ANC"
 	codeDbDailyGame      Code = "DB-DLY"
 	codeDbDailyGameGuess Code = "DB-DLG"
+	codeDbVirtualMachine Code = "DB-VMC"
+	codeDbBuildRecord    Code = "DB-BLD"
 )
 
 var responseCodeNames = map[Code]string{
@@ -39,6 +42,7 @@ var responseCodeNames = map[Code]string{
 	codeNotFound:           "Not Found",
 	codeInvalidCredentials: "Invalid Credentials",
 	codeAlreadyExists:      "Already Exists",
+	codeConflict:           "Conflict",
 
 	// Internal
 	codeInternalError: "Internal Error",
@@ -51,4 +55,6 @@ var responseCodeNames = map[Code]string{
 	codeDbAnswerCategory: "Database Answer Category Error",
 	codeDbDailyGame:      "Database Daily Game Error",
 	codeDbDailyGameGuess: "Database Daily Game Guess Error",
+	codeDbVirtualMachine: "Database Virtual Machine Error",
+	codeDbBuildRecord:    "Database Build Record Error",
 }
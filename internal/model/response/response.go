@@ -67,6 +67,18 @@ func ErrorDbDailyGameGuess[Resp response.Generic](resp Resp) {
 	setResponse(resp, codeDbDailyGameGuess)
 }
 
+func ErrorDbVirtualMachine[Resp response.Generic](resp Resp) {
+	setResponse(resp, codeDbVirtualMachine)
+}
+
+func ErrorDbBuildRecord[Resp response.Generic](resp Resp) {
+	setResponse(resp, codeDbBuildRecord)
+}
+
 func ErrorAlreadyExists[Resp response.Generic](resp Resp) {
 	setResponse(resp, codeAlreadyExists)
 }
+
+func ErrorConflict[Resp response.Generic](resp Resp) {
+	setResponse(resp, codeConflict)
+}
@@ -0,0 +1,21 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+const defaultBatchSize = 100
+
+// BatchCreate inserts items in chunks of batchSize rows, defaulting to 100.
+// An empty slice is a no-op.
+func BatchCreate[T any](ctx context.Context, db *gorm.DB, items []T, batchSize int) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return db.WithContext(ctx).CreateInBatches(items, batchSize).Error
+}
@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"github.com/cynxees/ra-server/internal/testutil"
+	"gorm.io/gorm"
+)
+
+// countInserts counts the INSERT statements db runs from now on
+func countInserts(t *testing.T, db *gorm.DB) *int {
+	t.Helper()
+	inserts := new(int)
+	err := db.Callback().Create().After("gorm:create").Register("test:count_inserts", func(*gorm.DB) {
+		*inserts++
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return inserts
+}
+
+func TestBatchCreate(t *testing.T) {
+	db := testutil.NewDB(t)
+	inserts := countInserts(t, db)
+
+	labels := make([]entity.VMLabel, 500)
+	for i := range labels {
+		labels[i] = entity.VMLabel{VMID: 1, Key: fmt.Sprintf("key-%d", i), Value: "v"}
+	}
+	if err := BatchCreate(context.Background(), db, labels, 0); err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+
+	var count int64
+	if err := db.Model(&entity.VMLabel{}).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 500 {
+		t.Errorf("rows = %d, want 500", count)
+	}
+	if *inserts != 500/defaultBatchSize {
+		t.Errorf("inserts = %d, want %d batches of %d", *inserts, 500/defaultBatchSize, defaultBatchSize)
+	}
+}
+
+func TestBatchCreateEmpty(t *testing.T) {
+	db := testutil.NewDB(t)
+	inserts := countInserts(t, db)
+
+	if err := BatchCreate(context.Background(), db, []entity.VMLabel{}, 10); err != nil {
+		t.Fatalf("BatchCreate: %v", err)
+	}
+	if *inserts != 0 {
+		t.Errorf("inserts = %d, want none for an empty slice", *inserts)
+	}
+}
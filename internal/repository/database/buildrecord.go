@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"gorm.io/gorm"
+)
+
+type BuildRecordRepo struct {
+	DB *gorm.DB
+}
+
+func NewBuildRecordRepo(db *gorm.DB) *BuildRecordRepo {
+	return &BuildRecordRepo{DB: db}
+}
+
+func (r *BuildRecordRepo) Create(ctx context.Context, record *entity.BuildRecord) error {
+	if record.Status == "" {
+		record.Status = constant.BuildStatusPending
+	}
+	return r.DB.WithContext(ctx).Create(record).Error
+}
+
+func (r *BuildRecordRepo) Get(ctx context.Context, id int32) (*entity.BuildRecord, error) {
+	var record entity.BuildRecord
+	err := r.DB.WithContext(ctx).First(&record, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// MarkRunning records that the build has started writing to logPath
+func (r *BuildRecordRepo) MarkRunning(ctx context.Context, id int32, logPath string) error {
+	return r.DB.WithContext(ctx).Model(&entity.BuildRecord{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     constant.BuildStatusRunning,
+		"started_at": time.Now(),
+		"log_path":   logPath,
+	}).Error
+}
+
+// MarkFinished records the build outcome; a non-nil buildErr marks it failed
+func (r *BuildRecordRepo) MarkFinished(ctx context.Context, id int32, artifactPath, checksum string, buildErr error) error {
+	updates := map[string]interface{}{
+		"status":        constant.BuildStatusDone,
+		"finished_at":   time.Now(),
+		"artifact_path": artifactPath,
+		"checksum":      checksum,
+	}
+	if buildErr != nil {
+		updates["status"] = constant.BuildStatusFailed
+		updates["error"] = buildErr.Error()
+	}
+	return r.DB.WithContext(ctx).Model(&entity.BuildRecord{}).Where("id = ?", id).Updates(updates).Error
+}
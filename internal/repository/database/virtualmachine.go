@@ -2,9 +2,12 @@ package database
 
 import (
 	"context"
+	"strings"
 
+	"github.com/cynxees/ra-server/internal/helper"
 	"github.com/cynxees/ra-server/internal/model/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type VirtualMachineRepo struct {
@@ -26,3 +29,133 @@ func (r *VirtualMachineRepo) Get(ctx context.Context, id int32) (*entity.Virtual
 	}
 	return &vm, nil
 }
+
+// NameTaken reports whether a VM other than excludeID already uses name
+func (r *VirtualMachineRepo) NameTaken(ctx context.Context, name string, excludeID int32) (bool, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&entity.VirtualMachine{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Delete removes the VM together with its labels
+func (r *VirtualMachineRepo) Delete(ctx context.Context, id int32) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("vm_id = ?", id).Delete(&entity.VMLabel{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.VirtualMachine{}, id).Error
+	})
+}
+
+// UpdateStatusFrom moves the VM to status only while it is still in from,
+// reporting whether it did, so a concurrent change is never overwritten
+func (r *VirtualMachineRepo) UpdateStatusFrom(ctx context.Context, id int32, from, status string) (bool, error) {
+	result := r.DB.WithContext(ctx).Model(&entity.VirtualMachine{}).Where("id = ? AND status = ?", id, from).Update("status", status)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpdateIPAddressByName sets the address of the VM backed by the named container
+func (r *VirtualMachineRepo) UpdateIPAddressByName(ctx context.Context, name, ipAddress string) error {
+	return r.DB.WithContext(ctx).Model(&entity.VirtualMachine{}).Where("name = ?", name).Update("ip_address", ipAddress).Error
+}
+
+func (r *VirtualMachineRepo) UpdateName(ctx context.Context, id int32, name string) error {
+	return r.DB.WithContext(ctx).Model(&entity.VirtualMachine{}).Where("id = ?", id).Update("name", name).Error
+}
+
+// VirtualMachineSortColumns maps the sort fields accepted from clients to
+// their columns. Anything outside this allowlist must be rejected before it
+// reaches a query.
+var VirtualMachineSortColumns = map[string]string{
+	"id":         "id",
+	"name":       "name",
+	"status":     "status",
+	"created_at": "created_date",
+}
+
+type VirtualMachineListFilter struct {
+	UserID *int32
+	// LabelSelector keeps VMs carrying every listed label with the given value
+	LabelSelector map[string]string
+	Status        string
+	NameContains  string
+	SortBy        string
+	Limit         int
+	Offset        int
+	SortDesc      bool
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escapes LIKE wildcards so the term matches literally
+func escapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
+
+// listQuery applies the filter, ordering and paging shared by List and ListSummaries
+func (r *VirtualMachineRepo) listQuery(ctx context.Context, filter VirtualMachineListFilter) *gorm.DB {
+	query := r.DB.WithContext(ctx).Model(&entity.VirtualMachine{})
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.NameContains != "" {
+		// The escape character is bound rather than inlined: a '\' literal
+		// reads differently under MySQL's default sql_mode and SQLite
+		query = query.Where("name LIKE ? ESCAPE ?", "%"+escapeLike(filter.NameContains)+"%", `\`)
+	}
+	for _, key := range helper.SortedKeys(filter.LabelSelector) {
+		query = query.Where(
+			"EXISTS (SELECT 1 FROM vm_label WHERE vm_label.vm_id = virtual_machine.id AND vm_label.label_key = ? AND vm_label.label_value = ?)",
+			key, filter.LabelSelector[key],
+		)
+	}
+
+	column, ok := VirtualMachineSortColumns[filter.SortBy]
+	if !ok {
+		column = "id"
+	}
+	query = query.Order(clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: filter.SortDesc})
+	if column != "id" {
+		// id breaks ties so pagination stays stable
+		query = query.Order("id")
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit).Offset(filter.Offset)
+	}
+	return query
+}
+
+func (r *VirtualMachineRepo) List(ctx context.Context, filter VirtualMachineListFilter) ([]entity.VirtualMachine, error) {
+	var vms []entity.VirtualMachine
+	if err := r.listQuery(ctx, filter).Find(&vms).Error; err != nil {
+		return nil, err
+	}
+	return vms, nil
+}
+
+// VirtualMachineSummary is the slim row used by list views
+type VirtualMachineSummary struct {
+	Name   string
+	Status string
+	Id     int32
+}
+
+// ListSummaries is List restricted to id, name and status, avoiding the
+// full column list QueryFields would otherwise select
+func (r *VirtualMachineRepo) ListSummaries(ctx context.Context, filter VirtualMachineListFilter) ([]VirtualMachineSummary, error) {
+	var summaries []VirtualMachineSummary
+	if err := r.listQuery(ctx, filter).Select("id", "name", "status").Find(&summaries).Error; err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
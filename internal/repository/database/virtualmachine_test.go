@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"github.com/cynxees/ra-server/internal/testutil"
+)
+
+func createVM(t *testing.T, repo *VirtualMachineRepo, vm entity.VirtualMachine) int32 {
+	t.Helper()
+	if vm.Type == "" {
+		vm.Type = "lxc"
+	}
+	if err := repo.DB.Create(&vm).Error; err != nil {
+		t.Fatalf("create vm %q: %v", vm.Name, err)
+	}
+	return vm.Id
+}
+
+func listIDs(t *testing.T, repo *VirtualMachineRepo, filter VirtualMachineListFilter) []int32 {
+	t.Helper()
+	vms, err := repo.List(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("List(%+v): %v", filter, err)
+	}
+	ids := make([]int32, len(vms))
+	for i, vm := range vms {
+		ids[i] = vm.Id
+	}
+	return ids
+}
+
+func TestListSortsByEveryAllowedField(t *testing.T) {
+	repo := NewVirtualMachineRepo(testutil.NewDB(t))
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Each column orders the rows differently from the others
+	charlie := createVM(t, repo, entity.VirtualMachine{Name: "charlie", Status: "stopped"})
+	alpha := createVM(t, repo, entity.VirtualMachine{Name: "alpha", Status: "running"})
+	bravo := createVM(t, repo, entity.VirtualMachine{Name: "bravo", Status: "active"})
+	for id, created := range map[int32]time.Time{charlie: day.AddDate(0, 0, 1), alpha: day.AddDate(0, 0, 2), bravo: day} {
+		if err := repo.DB.Model(&entity.VirtualMachine{}).Where("id = ?", id).UpdateColumn("created_date", created).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string][]int32{
+		"id":         {charlie, alpha, bravo},
+		"name":       {alpha, bravo, charlie},
+		"status":     {bravo, alpha, charlie},
+		"created_at": {bravo, charlie, alpha},
+	}
+	for field := range VirtualMachineSortColumns {
+		t.Run(field, func(t *testing.T) {
+			ascending, ok := want[field]
+			if !ok {
+				t.Fatalf("no expected order for sort field %q", field)
+			}
+			if got := listIDs(t, repo, VirtualMachineListFilter{SortBy: field}); !slices.Equal(got, ascending) {
+				t.Errorf("ascending = %v, want %v", got, ascending)
+			}
+
+			descending := slices.Clone(ascending)
+			slices.Reverse(descending)
+			if got := listIDs(t, repo, VirtualMachineListFilter{SortBy: field, SortDesc: true}); !slices.Equal(got, descending) {
+				t.Errorf("descending = %v, want %v", got, descending)
+			}
+		})
+	}
+}
+
+func TestListUnknownSortFieldFallsBackToID(t *testing.T) {
+	repo := NewVirtualMachineRepo(testutil.NewDB(t))
+	first := createVM(t, repo, entity.VirtualMachine{Name: "b"})
+	second := createVM(t, repo, entity.VirtualMachine{Name: "a"})
+
+	// The field never reaches the query as a column name
+	got := listIDs(t, repo, VirtualMachineListFilter{SortBy: "name; DROP TABLE virtual_machine"})
+	if want := []int32{first, second}; !slices.Equal(got, want) {
+		t.Errorf("List = %v, want %v", got, want)
+	}
+}
+
+func TestListNameContainsMatchesWildcardsLiterally(t *testing.T) {
+	repo := NewVirtualMachineRepo(testutil.NewDB(t))
+	percent := createVM(t, repo, entity.VirtualMachine{Name: "cpu-100%"})
+	underscore := createVM(t, repo, entity.VirtualMachine{Name: "web_1"})
+	backslash := createVM(t, repo, entity.VirtualMachine{Name: `dev\box`})
+	createVM(t, repo, entity.VirtualMachine{Name: "cpu-1000"})
+	createVM(t, repo, entity.VirtualMachine{Name: "web-1"})
+	createVM(t, repo, entity.VirtualMachine{Name: "devxbox"})
+
+	tests := []struct {
+		term string
+		want []int32
+	}{
+		{term: "100%", want: []int32{percent}},
+		{term: "b_1", want: []int32{underscore}},
+		{term: `v\b`, want: []int32{backslash}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.term, func(t *testing.T) {
+			if got := listIDs(t, repo, VirtualMachineListFilter{NameContains: tt.term}); !slices.Equal(got, tt.want) {
+				t.Errorf("List(NameContains=%q) = %v, want %v", tt.term, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListSummaries(t *testing.T) {
+	repo := NewVirtualMachineRepo(testutil.NewDB(t))
+	userID := int32(7)
+	web := createVM(t, repo, entity.VirtualMachine{Name: "web", Status: "running", UserID: userID, Description: "frontend"})
+	createVM(t, repo, entity.VirtualMachine{Name: "db", Status: "stopped", UserID: 8})
+	worker := createVM(t, repo, entity.VirtualMachine{Name: "worker", Status: "inactive", UserID: userID})
+
+	summaries, err := repo.ListSummaries(context.Background(), VirtualMachineListFilter{UserID: &userID, SortBy: "name"})
+	if err != nil {
+		t.Fatalf("ListSummaries: %v", err)
+	}
+
+	want := []VirtualMachineSummary{
+		{Id: web, Name: "web", Status: "running"},
+		{Id: worker, Name: "worker", Status: "inactive"},
+	}
+	if !slices.Equal(summaries, want) {
+		t.Errorf("ListSummaries = %+v, want %+v", summaries, want)
+	}
+}
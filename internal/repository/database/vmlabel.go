@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type VMLabelRepo struct {
+	DB *gorm.DB
+}
+
+func NewVMLabelRepo(db *gorm.DB) *VMLabelRepo {
+	return &VMLabelRepo{DB: db}
+}
+
+// Set adds the label or overwrites the value of an existing key on the VM
+func (r *VMLabelRepo) Set(ctx context.Context, vmID int32, key, value string) error {
+	label := &entity.VMLabel{VMID: vmID, Key: key, Value: value}
+	return r.DB.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "vm_id"}, {Name: "label_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"label_value", "updated_date"}),
+	}).Create(label).Error
+}
+
+// Remove deletes the label and reports whether it existed
+func (r *VMLabelRepo) Remove(ctx context.Context, vmID int32, key string) (bool, error) {
+	result := r.DB.WithContext(ctx).Where("vm_id = ? AND label_key = ?", vmID, key).Delete(&entity.VMLabel{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ListByVM returns the VM's labels keyed by label key
+func (r *VMLabelRepo) ListByVM(ctx context.Context, vmID int32) (map[string]string, error) {
+	var labels []entity.VMLabel
+	if err := r.DB.WithContext(ctx).Where("vm_id = ?", vmID).Find(&labels).Error; err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string, len(labels))
+	for _, label := range labels {
+		result[label.Key] = label.Value
+	}
+	return result, nil
+}
@@ -0,0 +1,132 @@
+package buildservice
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"github.com/cynxees/ra-server/internal/model/response"
+	"github.com/cynxees/ra-server/sandbox/images"
+)
+
+func (s *Service) BuildImage(ctx context.Context, req *pb.BuildImageRequest, resp *pb.BuildResponse) error {
+
+	kind := constant.BuildKind(req.Kind)
+	switch kind {
+	case constant.BuildKindLXC:
+	case constant.BuildKindQCOW2:
+		// Accepted as a kind, but there is no qcow2 builder to run it yet
+		response.ErrorNotAllowed(resp)
+		return fmt.Errorf("%s builds are not supported yet", kind)
+	default:
+		response.ErrorValidation(resp)
+		return fmt.Errorf("unsupported build kind %q", req.Kind)
+	}
+	if !images.HasContainerBuild(req.BaseImage) {
+		response.ErrorValidation(resp)
+		return fmt.Errorf("unknown base image %q", req.BaseImage)
+	}
+	if err := images.ValidateStaticIPv4(req.StaticIpv4, req.Ipv4Gateway); err != nil {
+		response.ErrorValidation(resp)
+		return err
+	}
+
+	opts := images.BuildOptions{StaticIPv4: req.StaticIpv4, IPv4Gateway: req.Ipv4Gateway}
+	hash, err := specHash(kind, req.BaseImage, opts)
+	if err != nil {
+		response.ErrorInternal(resp)
+		return err
+	}
+
+	record := &entity.BuildRecord{
+		Kind:     kind,
+		Base:     req.BaseImage,
+		SpecHash: hash,
+		Status:   constant.BuildStatusPending,
+	}
+	if err := s.BuildRecordRepo.Create(ctx, record); err != nil {
+		response.ErrorDbBuildRecord(resp)
+		return err
+	}
+
+	// The build outlives the request, so it must not inherit its cancellation
+	job := buildJob{
+		ctx:  context.WithoutCancel(ctx),
+		id:   record.Id,
+		base: record.Base,
+		opts: opts,
+	}
+	if err := s.enqueue(job); err != nil {
+		s.finishBuild(job.ctx, record.Id, "", "", err)
+		response.ErrorNotAllowed(resp)
+		return err
+	}
+
+	resp.Data = record.Response()
+	response.Success(resp)
+	return nil
+}
+
+// runBuild drives a build record from pending through running to done or
+// failed. Canceling ctx aborts the build, which is then recorded as failed.
+func (s *Service) runBuild(ctx context.Context, id int32, base string, opts images.BuildOptions) {
+	opts.OnStart = func(logPath string) {
+		if err := s.BuildRecordRepo.MarkRunning(context.WithoutCancel(ctx), id, logPath); err != nil {
+			logger.FromContext(ctx).Error("failed to mark build ", id, " running: ", err)
+		}
+	}
+	result, err := s.runner()(ctx, base, opts)
+
+	// The builder checksums the archive as it exports it
+	var artifactPath, checksum string
+	if err == nil && result != nil {
+		artifactPath, checksum = result.ArchivePath, result.Checksum
+		if result.IPAddress != "" {
+			s.recordIPAddress(ctx, result.ContainerName, result.IPAddress)
+		}
+	}
+
+	s.finishBuild(ctx, id, artifactPath, checksum, err)
+}
+
+// recordIPAddress stores a container's static address on the VM of the same
+// name, the way VMs and containers are matched when reconciling
+func (s *Service) recordIPAddress(ctx context.Context, containerName, ipAddress string) {
+	if s.VirtualMachineRepo == nil {
+		return
+	}
+	if err := s.VirtualMachineRepo.UpdateIPAddressByName(context.WithoutCancel(ctx), containerName, ipAddress); err != nil {
+		logger.FromContext(ctx).Error("failed to record address of container ", containerName, ": ", err)
+	}
+}
+
+// finishBuild records the build outcome, even once ctx is canceled
+func (s *Service) finishBuild(ctx context.Context, id int32, artifactPath, checksum string, buildErr error) {
+	if buildErr != nil {
+		logger.FromContext(ctx).Error("build ", id, " failed: ", buildErr)
+	}
+	if err := s.BuildRecordRepo.MarkFinished(context.WithoutCancel(ctx), id, artifactPath, checksum, buildErr); err != nil {
+		logger.FromContext(ctx).Error("failed to mark build ", id, " finished: ", err)
+	}
+}
+
+// specHash identifies the inputs of a build so identical requests can be
+// recognised. It covers every build option, not just the kind and base image.
+func specHash(kind constant.BuildKind, base string, opts images.BuildOptions) (string, error) {
+	spec, err := json.Marshal(struct {
+		Kind    constant.BuildKind
+		Base    string
+		Options images.BuildOptions
+	}{Kind: kind, Base: base, Options: opts})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode build spec: %w", err)
+	}
+	sum := sha256.Sum256(spec)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,217 @@
+package buildservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"github.com/cynxees/ra-server/internal/repository/database"
+	"github.com/cynxees/ra-server/internal/testutil"
+	"github.com/cynxees/ra-server/sandbox/images"
+)
+
+// newTestService returns a Service on a fresh test database that logs nowhere
+// and is stopped when the test ends
+func newTestService(t *testing.T, runner Runner) *Service {
+	t.Helper()
+
+	previous := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+
+	db := testutil.NewDB(t)
+	s := &Service{
+		BuildRecordRepo:    database.NewBuildRecordRepo(db),
+		VirtualMachineRepo: database.NewVirtualMachineRepo(db),
+		Runner:             runner,
+	}
+	t.Cleanup(func() { s.Stop(context.Background()) })
+	return s
+}
+
+func buildImage(t *testing.T, s *Service, kind constant.BuildKind) (*pb.BuildResponse, error) {
+	t.Helper()
+	return sendBuildImage(t, s, &pb.BuildImageRequest{Kind: string(kind), BaseImage: "ubuntu-base"})
+}
+
+func sendBuildImage(t *testing.T, s *Service, req *pb.BuildImageRequest) (*pb.BuildResponse, error) {
+	t.Helper()
+	resp := &pb.BuildResponse{Base: &core.BaseResponse{}}
+	err := s.BuildImage(context.Background(), req, resp)
+	return resp, err
+}
+
+// waitForStatus polls the build record until it reaches status
+func waitForStatus(t *testing.T, s *Service, id int32, status constant.BuildStatus) *entity.BuildRecord {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		record, err := s.BuildRecordRepo.Get(context.Background(), id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if record.Status == status {
+			return record
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("build %d status = %s, want %s", id, record.Status, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBuildImageProgresses(t *testing.T) {
+	const archive, checksum = "/builds/lxc-ubuntu-base.tar.gz", "9f86d081884c7d65"
+	release := make(chan struct{})
+	s := newTestService(t, func(ctx context.Context, name string, opts images.BuildOptions) (*images.BuildResult, error) {
+		opts.OnStart("build.log")
+		<-release
+		return &images.BuildResult{ArchivePath: archive, Checksum: checksum}, nil
+	})
+
+	resp, err := buildImage(t, s, constant.BuildKindLXC)
+	if err != nil {
+		t.Fatalf("BuildImage() error = %v", err)
+	}
+	if resp.Data.Id == 0 {
+		t.Fatal("BuildImage() returned no build id")
+	}
+
+	waitForStatus(t, s, resp.Data.Id, constant.BuildStatusRunning)
+	close(release)
+	if record := waitForStatus(t, s, resp.Data.Id, constant.BuildStatusDone); record.ArtifactPath != archive || record.Checksum != checksum {
+		t.Errorf("finished build = %+v, want artifact %s with checksum %s", record, archive, checksum)
+	}
+}
+
+func TestBuildImageRejectsQCOW2(t *testing.T) {
+	s := newTestService(t, nil)
+
+	resp, err := buildImage(t, s, constant.BuildKindQCOW2)
+	if err == nil || resp.Base.Code != "NA" {
+		t.Fatalf("BuildImage() = %s, %v, want NA", resp.Base.Code, err)
+	}
+}
+
+func TestStopCancelsRunningAndQueuedBuilds(t *testing.T) {
+	started := make(chan struct{})
+	s := newTestService(t, func(ctx context.Context, name string, opts images.BuildOptions) (*images.BuildResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	running, err := buildImage(t, s, constant.BuildKindLXC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	queued, err := buildImage(t, s, constant.BuildKindLXC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	if record := waitForStatus(t, s, running.Data.Id, constant.BuildStatusFailed); record.Error != context.Canceled.Error() {
+		t.Errorf("running build error = %q", record.Error)
+	}
+	if record := waitForStatus(t, s, queued.Data.Id, constant.BuildStatusFailed); record.Error != errBuildCanceled.Error() {
+		t.Errorf("queued build error = %q", record.Error)
+	}
+
+	if _, err := buildImage(t, s, constant.BuildKindLXC); !errors.Is(err, ErrQueueStopped) {
+		t.Errorf("BuildImage() after Stop error = %v, want ErrQueueStopped", err)
+	}
+}
+
+func TestBuildImageRecordsStaticIPAddress(t *testing.T) {
+	const archive = "/builds/lxc-ubuntu-base.tar.gz"
+	var got images.BuildOptions
+	s := newTestService(t, func(ctx context.Context, name string, opts images.BuildOptions) (*images.BuildResult, error) {
+		got = opts
+		return &images.BuildResult{ContainerName: name, ArchivePath: archive, IPAddress: "10.0.3.10"}, nil
+	})
+	vm := &entity.VirtualMachine{Name: "ubuntu-base", Type: "lxc", UserID: 1}
+	if err := s.VirtualMachineRepo.DB.Create(vm).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := sendBuildImage(t, s, &pb.BuildImageRequest{
+		Kind:        string(constant.BuildKindLXC),
+		BaseImage:   "ubuntu-base",
+		StaticIpv4:  "10.0.3.10/24",
+		Ipv4Gateway: "10.0.3.1",
+	})
+	if err != nil {
+		t.Fatalf("BuildImage() error = %v", err)
+	}
+	waitForStatus(t, s, resp.Data.Id, constant.BuildStatusDone)
+
+	if got.StaticIPv4 != "10.0.3.10/24" || got.IPv4Gateway != "10.0.3.1" {
+		t.Errorf("runner options = %s via %s, want the requested address", got.StaticIPv4, got.IPv4Gateway)
+	}
+	stored, err := s.VirtualMachineRepo.Get(context.Background(), vm.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.IPAddress != "10.0.3.10" {
+		t.Errorf("IPAddress = %q, want 10.0.3.10", stored.IPAddress)
+	}
+}
+
+func TestBuildImageRejectsInvalidStaticIPv4(t *testing.T) {
+	s := newTestService(t, nil)
+
+	resp, err := sendBuildImage(t, s, &pb.BuildImageRequest{
+		Kind:       string(constant.BuildKindLXC),
+		BaseImage:  "ubuntu-base",
+		StaticIpv4: "10.0.3.10",
+	})
+	if err == nil || resp.Base.Code != "VE" {
+		t.Fatalf("BuildImage() = %s, %v, want VE", resp.Base.Code, err)
+	}
+}
+
+func TestSpecHashCoversBuildOptions(t *testing.T) {
+	base := images.BuildOptions{StaticIPv4: "10.0.3.10/24", IPv4Gateway: "10.0.3.1"}
+	hash := func(kind constant.BuildKind, image string, opts images.BuildOptions) string {
+		t.Helper()
+		h, err := specHash(kind, image, opts)
+		if err != nil {
+			t.Fatalf("specHash() error = %v", err)
+		}
+		return h
+	}
+	want := hash(constant.BuildKindLXC, "ubuntu-base", base)
+
+	// OnStart is a callback, not an input of the build
+	withCallback := base
+	withCallback.OnStart = func(string) {}
+	if got := hash(constant.BuildKindLXC, "ubuntu-base", withCallback); got != want {
+		t.Errorf("specHash changed with OnStart: %s, want %s", got, want)
+	}
+
+	otherAddress := base
+	otherAddress.StaticIPv4 = "10.0.3.11/24"
+	otherMirror := base
+	otherMirror.AptMirror = "http://mirror.example.com/ubuntu"
+	for name, got := range map[string]string{
+		"kind":         hash(constant.BuildKindQCOW2, "ubuntu-base", base),
+		"base image":   hash(constant.BuildKindLXC, "ubuntu-java8", base),
+		"static ipv4":  hash(constant.BuildKindLXC, "ubuntu-base", otherAddress),
+		"apt mirror":   hash(constant.BuildKindLXC, "ubuntu-base", otherMirror),
+		"default opts": hash(constant.BuildKindLXC, "ubuntu-base", images.BuildOptions{}),
+	} {
+		if got == want {
+			t.Errorf("specHash ignores a different %s", name)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package buildservice
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/model/response"
+)
+
+func (s *Service) GetBuild(ctx context.Context, req *pb.GetBuildRequest, resp *pb.BuildResponse) error {
+
+	record, err := s.BuildRecordRepo.Get(ctx, req.Id)
+	if err != nil {
+		response.ErrorDbBuildRecord(resp)
+		return err
+	}
+	if record == nil {
+		response.ErrorNotFound(resp)
+		return fmt.Errorf("build %d not found", req.Id)
+	}
+
+	resp.Data = record.Response()
+	response.Success(resp)
+	return nil
+}
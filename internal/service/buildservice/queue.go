@@ -0,0 +1,125 @@
+package buildservice
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/cynxees/ra-server/sandbox/images"
+)
+
+// defaultQueueSize bounds how many builds can wait for the worker
+const defaultQueueSize = 32
+
+var (
+	ErrQueueFull     = errors.New("build queue is full")
+	ErrQueueStopped  = errors.New("build queue is stopped")
+	errBuildCanceled = errors.New("build canceled by server shutdown")
+)
+
+// buildJob is a pending build record waiting for the worker
+type buildJob struct {
+	// ctx carries the request's values but not its cancellation
+	ctx  context.Context
+	base string
+	opts images.BuildOptions
+	id   int32
+}
+
+// buildQueue hands build jobs to a single worker that Stop can cancel
+type buildQueue struct {
+	stopCtx context.Context
+	stop    context.CancelFunc
+	jobs    chan buildJob
+	done    chan struct{}
+	mu      sync.Mutex
+	started bool
+	stopped bool
+}
+
+// enqueue queues a build, starting the worker on first use
+func (s *Service) enqueue(job buildJob) error {
+	q := &s.queue
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.stopped {
+		return ErrQueueStopped
+	}
+	if !q.started {
+		size := s.QueueSize
+		if size <= 0 {
+			size = defaultQueueSize
+		}
+		q.stopCtx, q.stop = context.WithCancel(context.Background())
+		q.jobs = make(chan buildJob, size)
+		q.done = make(chan struct{})
+		q.started = true
+		go s.work()
+	}
+
+	select {
+	case q.jobs <- job:
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// work runs queued builds one at a time until the queue is stopped, then
+// fails the builds still waiting
+func (s *Service) work() {
+	q := &s.queue
+	defer close(q.done)
+
+	for {
+		select {
+		case <-q.stopCtx.Done():
+			s.failQueued()
+			return
+		case job := <-q.jobs:
+			// select picks at random when both are ready, so a stop can race a job
+			if q.stopCtx.Err() != nil {
+				s.finishBuild(job.ctx, job.id, "", "", errBuildCanceled)
+				continue
+			}
+			ctx, cancel := context.WithCancel(job.ctx)
+			stopBuild := context.AfterFunc(q.stopCtx, cancel)
+			s.runBuild(ctx, job.id, job.base, job.opts)
+			stopBuild()
+			cancel()
+		}
+	}
+}
+
+func (s *Service) failQueued() {
+	for {
+		select {
+		case job := <-s.queue.jobs:
+			s.finishBuild(job.ctx, job.id, "", "", errBuildCanceled)
+		default:
+			return
+		}
+	}
+}
+
+// Stop cancels the running build, fails the queued ones and waits for the
+// worker to exit or ctx to be done. Builds enqueued afterwards are rejected.
+func (s *Service) Stop(ctx context.Context) error {
+	q := &s.queue
+	q.mu.Lock()
+	q.stopped = true
+	started := q.started
+	q.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+	q.stop()
+	select {
+	case <-q.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
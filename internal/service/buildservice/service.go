@@ -0,0 +1,28 @@
+package buildservice
+
+import (
+	"context"
+
+	"github.com/cynxees/ra-server/internal/repository/database"
+	"github.com/cynxees/ra-server/sandbox/images"
+)
+
+// Runner builds the named image, calling opts.OnStart with the log path once the build begins
+type Runner func(ctx context.Context, name string, opts images.BuildOptions) (*images.BuildResult, error)
+
+type Service struct {
+	BuildRecordRepo    *database.BuildRecordRepo
+	VirtualMachineRepo *database.VirtualMachineRepo
+	// Runner defaults to images.BuildContainer when nil
+	Runner Runner
+	queue  buildQueue
+	// QueueSize bounds the builds waiting to run, defaultQueueSize when zero
+	QueueSize int
+}
+
+func (s *Service) runner() Runner {
+	if s.Runner != nil {
+		return s.Runner
+	}
+	return images.BuildContainer
+}
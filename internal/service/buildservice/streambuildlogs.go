@@ -0,0 +1,55 @@
+package buildservice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/helper"
+	"github.com/cynxees/ra-server/internal/model/entity"
+)
+
+// logPollInterval is how often a build is re-checked while waiting for new log output
+const logPollInterval = 500 * time.Millisecond
+
+var ErrBuildNotFound = errors.New("build not found")
+
+// StreamBuildLogs sends each line of the build's log to send as it is written,
+// returning once the build has finished and its log has been fully read
+func (s *Service) StreamBuildLogs(ctx context.Context, id int32, send func(line string) error) error {
+	record, err := s.BuildRecordRepo.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return ErrBuildNotFound
+	}
+
+	// A pending build has no log file until the runner picks it up
+	for record.LogPath == "" {
+		if isFinished(record) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(logPollInterval):
+		}
+		if record, err = s.BuildRecordRepo.Get(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return helper.FollowFile(ctx, record.LogPath, logPollInterval, func() (bool, error) {
+		record, err := s.BuildRecordRepo.Get(ctx, id)
+		if err != nil || record == nil {
+			return false, err
+		}
+		return isFinished(record), nil
+	}, send)
+}
+
+func isFinished(record *entity.BuildRecord) bool {
+	return record.Status == constant.BuildStatusDone || record.Status == constant.BuildStatusFailed
+}
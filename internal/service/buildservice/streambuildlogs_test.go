@@ -0,0 +1,96 @@
+package buildservice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/sandbox/images"
+)
+
+// appendLine adds a line to the log at path
+func appendLine(path, line string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.WriteString(line + "\n")
+	return err
+}
+
+func TestStreamBuildLogsFollowsUntilBuildFinishes(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "build.log")
+	release := make(chan struct{})
+	s := newTestService(t, func(ctx context.Context, name string, opts images.BuildOptions) (*images.BuildResult, error) {
+		if err := appendLine(logPath, "creating container"); err != nil {
+			return nil, err
+		}
+		opts.OnStart(logPath)
+		<-release
+		if err := appendLine(logPath, "exporting archive"); err != nil {
+			return nil, err
+		}
+		return &images.BuildResult{ArchivePath: "/builds/lxc-ubuntu-base.tar.gz"}, nil
+	})
+
+	resp, err := buildImage(t, s, constant.BuildKindLXC)
+	if err != nil {
+		t.Fatalf("BuildImage() error = %v", err)
+	}
+
+	lines := make(chan string, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- s.StreamBuildLogs(context.Background(), resp.Data.Id, func(line string) error {
+			lines <- line
+			return nil
+		})
+	}()
+
+	// Lines written while the build runs arrive before it finishes
+	expectLine := func(want string) {
+		t.Helper()
+		select {
+		case line := <-lines:
+			if line != want {
+				t.Fatalf("line = %q, want %q", line, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no log line, want %q", want)
+		}
+	}
+	expectLine("creating container")
+	select {
+	case err := <-done:
+		t.Fatalf("StreamBuildLogs returned %v while the build was running", err)
+	default:
+	}
+
+	close(release)
+	expectLine("exporting archive")
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("StreamBuildLogs() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("StreamBuildLogs did not return after the build finished")
+	}
+	if len(lines) != 0 {
+		t.Errorf("unexpected extra line %q", <-lines)
+	}
+}
+
+func TestStreamBuildLogsUnknownBuild(t *testing.T) {
+	s := newTestService(t, nil)
+
+	err := s.StreamBuildLogs(context.Background(), 404, func(string) error { return nil })
+	if !errors.Is(err, ErrBuildNotFound) {
+		t.Errorf("StreamBuildLogs() error = %v, want ErrBuildNotFound", err)
+	}
+}
@@ -0,0 +1,113 @@
+package healthservice
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/cynxees/ra-server/internal/dependencies"
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/helper"
+)
+
+const (
+	ComponentDatabase = "database"
+	ComponentDisk     = "disk"
+	ComponentTools    = "tools"
+
+	defaultBuildDir      = "sandbox/build"
+	defaultMinFreeDiskMb = 1024
+)
+
+// requiredTools are the host binaries the container builders and the QEMU
+// launcher shell out to. packer is not checked since nothing runs it yet.
+var requiredTools = []string{"lxc-create", "lxc-start", "lxc-attach", "lxc-info", "tar", "qemu-system-x86_64"}
+
+// lookPath and freeDiskSpace are swapped out in tests
+var (
+	lookPath      = exec.LookPath
+	freeDiskSpace = helper.FreeDiskSpace
+)
+
+type Service struct {
+	DatabaseClient *dependencies.DatabaseClient
+}
+
+type ComponentStatus struct {
+	Name    string `json:"name"`
+	Detail  string `json:"detail,omitempty"`
+	Healthy bool   `json:"healthy"`
+}
+
+type HealthReport struct {
+	Components []ComponentStatus `json:"components"`
+	Healthy    bool              `json:"healthy"`
+}
+
+// Component returns the status of the named component
+func (r HealthReport) Component(name string) (ComponentStatus, bool) {
+	for _, component := range r.Components {
+		if component.Name == name {
+			return component, true
+		}
+	}
+	return ComponentStatus{}, false
+}
+
+func (s *Service) Check(ctx context.Context) HealthReport {
+	report := HealthReport{
+		Components: []ComponentStatus{
+			s.checkDatabase(ctx),
+			checkDisk(),
+			checkTools(),
+		},
+		Healthy: true,
+	}
+
+	for _, component := range report.Components {
+		report.Healthy = report.Healthy && component.Healthy
+	}
+	return report
+}
+
+func (s *Service) checkDatabase(ctx context.Context) ComponentStatus {
+	if err := s.DatabaseClient.Ping(ctx); err != nil {
+		return ComponentStatus{Name: ComponentDatabase, Detail: err.Error()}
+	}
+	return ComponentStatus{Name: ComponentDatabase, Healthy: true}
+}
+
+func checkDisk() ComponentStatus {
+	health := config.Get().Health
+	buildDir := health.BuildDir
+	if buildDir == "" {
+		buildDir = defaultBuildDir
+	}
+	minFreeMb := health.MinFreeDiskMb
+	if minFreeMb <= 0 {
+		minFreeMb = defaultMinFreeDiskMb
+	}
+
+	free, err := freeDiskSpace(buildDir)
+	if err != nil {
+		return ComponentStatus{Name: ComponentDisk, Detail: err.Error()}
+	}
+
+	freeMb := free / (1024 * 1024)
+	detail := fmt.Sprintf("%d MB free in %s, %d MB required", freeMb, buildDir, minFreeMb)
+	return ComponentStatus{Name: ComponentDisk, Detail: detail, Healthy: freeMb >= uint64(minFreeMb)}
+}
+
+func checkTools() ComponentStatus {
+	var missing []string
+	for _, tool := range requiredTools {
+		if _, err := lookPath(tool); err != nil {
+			missing = append(missing, tool)
+		}
+	}
+
+	if len(missing) > 0 {
+		return ComponentStatus{Name: ComponentTools, Detail: fmt.Sprintf("missing: %v", missing)}
+	}
+	return ComponentStatus{Name: ComponentTools, Healthy: true}
+}
@@ -0,0 +1,59 @@
+package healthservice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+)
+
+// stubHost reports freeMb of disk space and every tool present except missing
+func stubHost(t *testing.T, freeMb uint64, missing ...string) {
+	t.Helper()
+
+	previousConfig := config.Set(&config.AppConfig{Health: config.HealthConfig{BuildDir: t.TempDir(), MinFreeDiskMb: 100}})
+	previousLookPath, previousFree := lookPath, freeDiskSpace
+	t.Cleanup(func() {
+		config.Set(previousConfig)
+		lookPath, freeDiskSpace = previousLookPath, previousFree
+	})
+
+	lookPath = func(tool string) (string, error) {
+		for _, name := range missing {
+			if tool == name {
+				return "", errors.New("not found")
+			}
+		}
+		return "/usr/bin/" + tool, nil
+	}
+	freeDiskSpace = func(string) (uint64, error) { return freeMb * 1024 * 1024, nil }
+}
+
+func TestCheckHealthyHost(t *testing.T) {
+	stubHost(t, 500)
+
+	if status := checkDisk(); !status.Healthy {
+		t.Errorf("checkDisk() = %+v, want healthy", status)
+	}
+	if status := checkTools(); !status.Healthy {
+		t.Errorf("checkTools() = %+v, want healthy", status)
+	}
+}
+
+func TestCheckDiskLowSpace(t *testing.T) {
+	stubHost(t, 50)
+
+	status := checkDisk()
+	if status.Healthy {
+		t.Errorf("checkDisk() = %+v, want unhealthy", status)
+	}
+}
+
+func TestCheckToolsMissingQemu(t *testing.T) {
+	stubHost(t, 500, "qemu-system-x86_64")
+
+	status := checkTools()
+	if status.Healthy || status.Detail != "missing: [qemu-system-x86_64]" {
+		t.Errorf("checkTools() = %+v, want qemu-system-x86_64 missing", status)
+	}
+}
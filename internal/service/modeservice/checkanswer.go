@@ -0,0 +1,35 @@
+package modeservice
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/model/response"
+)
+
+// CheckAnswer reports whether the guess matches the answer under the matcher
+// app.matchers configures for the mode
+func (s *Service) CheckAnswer(ctx context.Context, req *pb.CheckAnswerRequest, resp *pb.CheckAnswerResponse) error {
+
+	modeType, err := AcceptMode(req.Mode)
+	if errors.Is(err, ErrModeDisabled) {
+		response.ErrorNotAllowed(resp)
+		return err
+	}
+	if err != nil {
+		response.ErrorValidation(resp)
+		return err
+	}
+
+	matcher, err := config.Get().App.MatcherFor(modeType)
+	if err != nil {
+		response.ErrorInternal(resp)
+		return err
+	}
+
+	resp.Correct = matcher.Match(req.Guess, req.Answer)
+	response.Success(resp)
+	return nil
+}
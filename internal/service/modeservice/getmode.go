@@ -0,0 +1,32 @@
+package modeservice
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/model/response"
+)
+
+func (s *Service) GetMode(ctx context.Context, req *pb.GetModeRequest, resp *pb.ModeResponse) error {
+
+	modeType, err := AcceptMode(req.Mode)
+	if errors.Is(err, ErrModeDisabled) {
+		response.ErrorNotAllowed(resp)
+		return err
+	}
+	if err != nil {
+		response.ErrorValidation(resp)
+		return err
+	}
+
+	info, err := modeInfo(modeType)
+	if err != nil {
+		response.ErrorInternal(resp)
+		return err
+	}
+
+	resp.Data = info
+	response.Success(resp)
+	return nil
+}
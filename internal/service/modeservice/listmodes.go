@@ -0,0 +1,32 @@
+package modeservice
+
+import (
+	"context"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/model/response"
+)
+
+// ListModes returns the modes this server serves, in declaration order
+func (s *Service) ListModes(ctx context.Context, req *pb.ListModesRequest, resp *pb.ListModesResponse) error {
+
+	app := config.Get().App
+	modes := []*pb.ModeInfo{}
+	for _, modeType := range constant.ModeTypes {
+		if !app.IsModeEnabled(modeType) {
+			continue
+		}
+		info, err := modeInfo(modeType)
+		if err != nil {
+			response.ErrorInternal(resp)
+			return err
+		}
+		modes = append(modes, info)
+	}
+
+	resp.Data = modes
+	response.Success(resp)
+	return nil
+}
@@ -0,0 +1,37 @@
+package modeservice
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+	"github.com/cynxees/ra-server/internal/model/entity"
+)
+
+// ErrModeDisabled is returned for a known mode that app.enabledModes leaves out
+var ErrModeDisabled = errors.New("mode is disabled")
+
+type Service struct{}
+
+// AcceptMode converts a mode sent by a client, rejecting unknown modes and
+// ones app.enabledModes leaves out. Every RPC that takes a mode goes through it.
+func AcceptMode(mode pb.Mode) (constant.ModeType, error) {
+	modeType, err := entity.ModeFromProto(mode)
+	if err != nil {
+		return "", err
+	}
+	if !config.Get().App.IsModeEnabled(modeType) {
+		return "", fmt.Errorf("%w: %s", ErrModeDisabled, modeType)
+	}
+	return modeType, nil
+}
+
+func modeInfo(modeType constant.ModeType) (*pb.ModeInfo, error) {
+	mode, err := entity.ModeToProto(modeType)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ModeInfo{Mode: mode, Category: modeType.Category()}, nil
+}
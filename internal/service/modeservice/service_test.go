@@ -0,0 +1,146 @@
+package modeservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/dependencies/config"
+)
+
+// useConfig installs cfg as the running config for the test
+func useConfig(t *testing.T, cfg *config.AppConfig) {
+	t.Helper()
+	previous := config.Set(cfg)
+	t.Cleanup(func() { config.Set(previous) })
+}
+
+// useEnabledModes installs a config enabling only modes for the test
+func useEnabledModes(t *testing.T, modes ...constant.ModeType) {
+	t.Helper()
+	cfg := &config.AppConfig{}
+	for _, mode := range modes {
+		cfg.App.EnabledModes = append(cfg.App.EnabledModes, string(mode))
+	}
+	useConfig(t, cfg)
+}
+
+func TestGetMode(t *testing.T) {
+	useEnabledModes(t, constant.ModeTypeWordle)
+
+	tests := []struct {
+		wantErr  error
+		name     string
+		wantCode string
+		mode     pb.Mode
+	}{
+		{name: "enabled", mode: pb.Mode_MODE_WORDLE, wantCode: "00"},
+		{name: "disabled", mode: pb.Mode_MODE_SUDOKU, wantCode: "NA", wantErr: ErrModeDisabled},
+		{name: "unspecified", mode: pb.Mode_MODE_UNSPECIFIED, wantCode: "VE"},
+		{name: "unknown", mode: pb.Mode(9999), wantCode: "VE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &pb.ModeResponse{Base: &core.BaseResponse{}}
+			err := (&Service{}).GetMode(context.Background(), &pb.GetModeRequest{Mode: tt.mode}, resp)
+
+			if resp.Base.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q (err %v)", resp.Base.Code, tt.wantCode, err)
+			}
+			if tt.wantCode == "00" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantCode != "00" && err == nil {
+				t.Error("expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetModeReturnsCategory(t *testing.T) {
+	useEnabledModes(t)
+
+	resp := &pb.ModeResponse{Base: &core.BaseResponse{}}
+	if err := (&Service{}).GetMode(context.Background(), &pb.GetModeRequest{Mode: pb.Mode_MODE_ESCAPE_ROOM}, resp); err != nil {
+		t.Fatalf("GetMode: %v", err)
+	}
+	if resp.Data.Category != constant.ModeTypeEscapeRoom.Category() {
+		t.Errorf("category = %q, want %q", resp.Data.Category, constant.ModeTypeEscapeRoom.Category())
+	}
+}
+
+func TestListModes(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled []constant.ModeType
+		want    int
+	}{
+		{name: "all modes when unrestricted", want: len(constant.ModeTypes)},
+		{name: "only enabled modes", enabled: []constant.ModeType{constant.ModeTypeSudoku, constant.ModeTypeWordle}, want: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useEnabledModes(t, tt.enabled...)
+
+			resp := &pb.ListModesResponse{Base: &core.BaseResponse{}}
+			if err := (&Service{}).ListModes(context.Background(), &pb.ListModesRequest{}, resp); err != nil {
+				t.Fatalf("ListModes: %v", err)
+			}
+			if len(resp.Data) != tt.want {
+				t.Fatalf("listed %d modes, want %d", len(resp.Data), tt.want)
+			}
+			if tt.enabled != nil && (resp.Data[0].Mode != pb.Mode_MODE_WORDLE || resp.Data[1].Mode != pb.Mode_MODE_SUDOKU) {
+				t.Errorf("modes = %v, want declaration order [WORDLE SUDOKU]", resp.Data)
+			}
+		})
+	}
+}
+
+func TestCheckAnswerUsesConfiguredMatcher(t *testing.T) {
+	tests := []struct {
+		matchers map[string]string
+		name     string
+		guess    string
+		correct  bool
+	}{
+		{name: "default fuzzy accepts a typo", guess: "a shadow", correct: true},
+		{name: "default fuzzy ignores case", guess: "A SHADOWW", correct: true},
+		{name: "exact override rejects a typo", matchers: map[string]string{"riddles": "exact"}, guess: "a shadoww"},
+		{name: "exact override rejects case", matchers: map[string]string{"RIDDLES": "exact"}, guess: "A Shadow"},
+		{name: "case insensitive override", matchers: map[string]string{"riddles": "case_insensitive"}, guess: "A Shadow", correct: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useConfig(t, &config.AppConfig{App: config.App{Matchers: tt.matchers}})
+
+			resp := &pb.CheckAnswerResponse{Base: &core.BaseResponse{}}
+			req := &pb.CheckAnswerRequest{Mode: pb.Mode_MODE_RIDDLES, Guess: tt.guess, Answer: "a shadow"}
+			if err := (&Service{}).CheckAnswer(context.Background(), req, resp); err != nil {
+				t.Fatalf("CheckAnswer() error = %v", err)
+			}
+			if resp.Correct != tt.correct {
+				t.Errorf("Correct = %t, want %t", resp.Correct, tt.correct)
+			}
+		})
+	}
+}
+
+func TestCheckAnswerRejectsDisabledMode(t *testing.T) {
+	useEnabledModes(t, constant.ModeTypeWordle)
+
+	resp := &pb.CheckAnswerResponse{Base: &core.BaseResponse{}}
+	req := &pb.CheckAnswerRequest{Mode: pb.Mode_MODE_RIDDLES, Guess: "a", Answer: "a"}
+	err := (&Service{}).CheckAnswer(context.Background(), req, resp)
+	if !errors.Is(err, ErrModeDisabled) || resp.Base.Code != "NA" {
+		t.Errorf("CheckAnswer() = %s, %v, want NA", resp.Base.Code, err)
+	}
+}
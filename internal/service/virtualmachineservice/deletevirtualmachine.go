@@ -0,0 +1,46 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/model/response"
+)
+
+func (s *Service) DeleteVirtualMachine(ctx context.Context, req *pb.DeleteVirtualMachineRequest, resp *pb.VirtualMachineResponse) error {
+
+	vm, err := s.VirtualMachineRepo.Get(ctx, req.Id)
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+	if vm == nil {
+		response.ErrorNotFound(resp)
+		return fmt.Errorf("virtual machine %d not found", req.Id)
+	}
+
+	if vm.Status == constant.VirtualMachineStatusRunning || vm.Status == constant.VirtualMachineStatusProvisioning {
+		if !req.Force {
+			response.ErrorConflict(resp)
+			return fmt.Errorf("virtual machine %d is %s; stop it first or set force", vm.Id, vm.Status)
+		}
+
+		logger.FromContext(ctx).Warn("Force deleting ", vm.Status, " virtual machine ", vm.Id)
+		if err := s.stopContainer(ctx, vm.Name); err != nil {
+			response.ErrorInternal(resp)
+			return err
+		}
+	}
+
+	if err := s.VirtualMachineRepo.Delete(ctx, vm.Id); err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+
+	resp.Data = vm.Response()
+	response.Success(resp)
+	return nil
+}
@@ -0,0 +1,58 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+func TestDeleteVirtualMachine(t *testing.T) {
+	tests := []struct {
+		stopErr     error
+		name        string
+		status      string
+		wantCode    string
+		wantStopped bool
+		force       bool
+		wantDeleted bool
+	}{
+		{name: "inactive", status: constant.VirtualMachineStatusInactive, wantCode: "00", wantDeleted: true},
+		{name: "running without force", status: constant.VirtualMachineStatusRunning, wantCode: "CF"},
+		{name: "provisioning without force", status: constant.VirtualMachineStatusProvisioning, wantCode: "CF"},
+		{name: "running with force", status: constant.VirtualMachineStatusRunning, force: true, wantCode: "00", wantStopped: true, wantDeleted: true},
+		{name: "force stop fails", status: constant.VirtualMachineStatusRunning, force: true, stopErr: errors.New("lxc-stop failed"), wantCode: "I-IE", wantStopped: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			var stopped string
+			s.StopContainer = func(_ context.Context, name string) error {
+				stopped = name
+				return tt.stopErr
+			}
+			vm := createVM(t, s, "vm-a", tt.status)
+
+			resp := &pb.VirtualMachineResponse{Base: &core.BaseResponse{}}
+			err := s.DeleteVirtualMachine(context.Background(), &pb.DeleteVirtualMachineRequest{Id: vm.Id, Force: tt.force}, resp)
+			if resp.Base.Code != tt.wantCode || (err == nil) != (tt.wantCode == "00") {
+				t.Fatalf("DeleteVirtualMachine() = %s, %v, want %s", resp.Base.Code, err, tt.wantCode)
+			}
+			if (stopped == "vm-a") != tt.wantStopped {
+				t.Errorf("stopped container %q, want stopped %t", stopped, tt.wantStopped)
+			}
+
+			stored, err := s.VirtualMachineRepo.Get(context.Background(), vm.Id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (stored == nil) != tt.wantDeleted {
+				t.Errorf("deleted = %t, want %t", stored == nil, tt.wantDeleted)
+			}
+		})
+	}
+}
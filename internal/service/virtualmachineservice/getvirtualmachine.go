@@ -2,10 +2,25 @@ package virtualmachineservice
 
 import (
 	"context"
+	"fmt"
+
 	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/model/response"
 )
 
 func (s *Service) GetVirtualMachine(ctx context.Context, req *pb.GetVirtualMachineRequest, resp *pb.VirtualMachineResponse) error {
 
+	vm, err := s.VirtualMachineRepo.Get(ctx, req.Id)
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+	if vm == nil {
+		response.ErrorNotFound(resp)
+		return fmt.Errorf("virtual machine %d not found", req.Id)
+	}
+
+	resp.Data = vm.Response()
+	response.Success(resp)
 	return nil
 }
@@ -0,0 +1,36 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+func TestGetVirtualMachine(t *testing.T) {
+	s := newTestService(t)
+	vm := createVM(t, s, "vm-a", constant.VirtualMachineStatusInactive)
+
+	resp := &pb.VirtualMachineResponse{Base: &core.BaseResponse{}}
+	if err := s.GetVirtualMachine(context.Background(), &pb.GetVirtualMachineRequest{Id: vm.Id}, resp); err != nil {
+		t.Fatalf("GetVirtualMachine: %v", err)
+	}
+	if resp.Base.Code != "00" || resp.Data.GetName() != "vm-a" {
+		t.Errorf("response = %s %v, want vm-a", resp.Base.Code, resp.Data)
+	}
+}
+
+func TestGetVirtualMachineNotFound(t *testing.T) {
+	s := newTestService(t)
+
+	resp := &pb.VirtualMachineResponse{Base: &core.BaseResponse{}}
+	err := s.GetVirtualMachine(context.Background(), &pb.GetVirtualMachineRequest{Id: 404}, resp)
+	if err == nil {
+		t.Error("expected an error for a missing id")
+	}
+	if resp.Base.Code != "NF" || resp.Data != nil {
+		t.Errorf("response = %s %v, want NF without data", resp.Base.Code, resp.Data)
+	}
+}
@@ -0,0 +1,81 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/model/response"
+)
+
+var (
+	// labelKeyPattern allows up to 63 characters, starting and ending alphanumeric
+	labelKeyPattern   = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9._/-]{0,61}[A-Za-z0-9])?$`)
+	labelValuePattern = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9._-]{0,61}[A-Za-z0-9])?)?$`)
+)
+
+func (s *Service) SetVirtualMachineLabel(ctx context.Context, req *pb.SetVirtualMachineLabelRequest, resp *pb.VirtualMachineLabelsResponse) error {
+
+	if !labelKeyPattern.MatchString(req.Key) {
+		response.ErrorValidation(resp)
+		return fmt.Errorf("invalid label key %q", req.Key)
+	}
+	if !labelValuePattern.MatchString(req.Value) {
+		response.ErrorValidation(resp)
+		return fmt.Errorf("invalid label value %q", req.Value)
+	}
+
+	if err := s.checkVirtualMachineExists(ctx, req.Id, resp); err != nil {
+		return err
+	}
+
+	if err := s.VMLabelRepo.Set(ctx, req.Id, req.Key, req.Value); err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+	return s.respondLabels(ctx, req.Id, resp)
+}
+
+func (s *Service) RemoveVirtualMachineLabel(ctx context.Context, req *pb.RemoveVirtualMachineLabelRequest, resp *pb.VirtualMachineLabelsResponse) error {
+
+	if err := s.checkVirtualMachineExists(ctx, req.Id, resp); err != nil {
+		return err
+	}
+
+	removed, err := s.VMLabelRepo.Remove(ctx, req.Id, req.Key)
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+	if !removed {
+		response.ErrorNotFound(resp)
+		return fmt.Errorf("virtual machine %d has no label %q", req.Id, req.Key)
+	}
+	return s.respondLabels(ctx, req.Id, resp)
+}
+
+func (s *Service) checkVirtualMachineExists(ctx context.Context, id int32, resp *pb.VirtualMachineLabelsResponse) error {
+	vm, err := s.VirtualMachineRepo.Get(ctx, id)
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+	if vm == nil {
+		response.ErrorNotFound(resp)
+		return fmt.Errorf("virtual machine %d not found", id)
+	}
+	return nil
+}
+
+func (s *Service) respondLabels(ctx context.Context, id int32, resp *pb.VirtualMachineLabelsResponse) error {
+	labels, err := s.VMLabelRepo.ListByVM(ctx, id)
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+
+	resp.Labels = labels
+	response.Success(resp)
+	return nil
+}
@@ -0,0 +1,80 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+func setLabel(t *testing.T, s *Service, id int32, key, value string) *pb.VirtualMachineLabelsResponse {
+	t.Helper()
+	resp := &pb.VirtualMachineLabelsResponse{Base: &core.BaseResponse{}}
+	if err := s.SetVirtualMachineLabel(context.Background(), &pb.SetVirtualMachineLabelRequest{Id: id, Key: key, Value: value}, resp); err != nil {
+		t.Fatalf("SetVirtualMachineLabel(%s=%s): %v", key, value, err)
+	}
+	return resp
+}
+
+func TestSetVirtualMachineLabelOverwritesKey(t *testing.T) {
+	s := newTestService(t)
+	vm := createVM(t, s, "vm-a", constant.VirtualMachineStatusInactive)
+
+	setLabel(t, s, vm.Id, "env", "staging")
+	setLabel(t, s, vm.Id, "team", "infra")
+	resp := setLabel(t, s, vm.Id, "env", "prod")
+
+	if len(resp.Labels) != 2 || resp.Labels["env"] != "prod" || resp.Labels["team"] != "infra" {
+		t.Errorf("labels = %v, want env=prod team=infra", resp.Labels)
+	}
+}
+
+func TestListVirtualMachinesByLabelSelector(t *testing.T) {
+	s := newTestService(t)
+	stagingInfra := createVM(t, s, "vm-a", constant.VirtualMachineStatusInactive)
+	stagingWeb := createVM(t, s, "vm-b", constant.VirtualMachineStatusInactive)
+	prodInfra := createVM(t, s, "vm-c", constant.VirtualMachineStatusInactive)
+	createVM(t, s, "vm-d", constant.VirtualMachineStatusInactive)
+
+	setLabel(t, s, stagingInfra.Id, "env", "staging")
+	setLabel(t, s, stagingInfra.Id, "team", "infra")
+	setLabel(t, s, stagingWeb.Id, "env", "staging")
+	setLabel(t, s, stagingWeb.Id, "team", "web")
+	setLabel(t, s, prodInfra.Id, "env", "prod")
+	setLabel(t, s, prodInfra.Id, "team", "infra")
+
+	tests := []struct {
+		selector map[string]string
+		name     string
+		want     []string
+	}{
+		{name: "no selector", want: []string{"vm-a", "vm-b", "vm-c", "vm-d"}},
+		{name: "one label", selector: map[string]string{"env": "staging"}, want: []string{"vm-a", "vm-b"}},
+		{name: "every label must match", selector: map[string]string{"env": "staging", "team": "infra"}, want: []string{"vm-a"}},
+		{name: "no match", selector: map[string]string{"env": "dev"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &pb.ListVirtualMachinesResponse{Base: &core.BaseResponse{}}
+			if err := s.ListVirtualMachines(context.Background(), &pb.ListVirtualMachinesRequest{LabelSelector: tt.selector}, resp); err != nil {
+				t.Fatalf("ListVirtualMachines: %v", err)
+			}
+
+			var got []string
+			for _, vm := range resp.Data {
+				got = append(got, vm.Name)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("names = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("names = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/model/response"
+	"github.com/cynxees/ra-server/internal/repository/database"
+)
+
+const (
+	defaultListPageSize = 20
+	maxListPageSize     = 100
+)
+
+func (s *Service) ListVirtualMachines(ctx context.Context, req *pb.ListVirtualMachinesRequest, resp *pb.ListVirtualMachinesResponse) error {
+
+	if req.SortBy != "" {
+		if _, ok := database.VirtualMachineSortColumns[req.SortBy]; !ok {
+			response.ErrorValidation(resp)
+			return fmt.Errorf("unknown sort field %q", req.SortBy)
+		}
+	}
+
+	page := max(int(req.Page), 1)
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultListPageSize
+	}
+	pageSize = min(pageSize, maxListPageSize)
+
+	vms, err := s.VirtualMachineRepo.List(ctx, database.VirtualMachineListFilter{
+		UserID:        req.UserId,
+		Status:        req.Status,
+		NameContains:  req.NameContains,
+		LabelSelector: req.LabelSelector,
+		SortBy:        req.SortBy,
+		SortDesc:      req.SortDesc,
+		Limit:         pageSize,
+		Offset:        (page - 1) * pageSize,
+	})
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+
+	resp.Data = make([]*pb.VirtualMachine, 0, len(vms))
+	for _, vm := range vms {
+		resp.Data = append(resp.Data, vm.Response())
+	}
+
+	response.Success(resp)
+	return nil
+}
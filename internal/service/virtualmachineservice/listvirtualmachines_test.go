@@ -0,0 +1,27 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+func TestListVirtualMachinesRejectsUnknownSortField(t *testing.T) {
+	s := newTestService(t)
+	createVM(t, s, "vm-a", constant.VirtualMachineStatusInactive)
+
+	resp := &pb.ListVirtualMachinesResponse{Base: &core.BaseResponse{}}
+	err := s.ListVirtualMachines(context.Background(), &pb.ListVirtualMachinesRequest{SortBy: "password"}, resp)
+	if err == nil {
+		t.Fatal("ListVirtualMachines accepted an unknown sort field")
+	}
+	if resp.Base.Code != "VE" {
+		t.Errorf("code = %s, want VE", resp.Base.Code)
+	}
+	if len(resp.Data) != 0 {
+		t.Errorf("returned %d VMs for a rejected request", len(resp.Data))
+	}
+}
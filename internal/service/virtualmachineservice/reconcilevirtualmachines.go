@@ -0,0 +1,74 @@
+package virtualmachineservice
+
+import (
+	"context"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/model/response"
+	"github.com/cynxees/ra-server/internal/repository/database"
+)
+
+// reconciledStatus returns the status a VM should have given its container's
+// state, or false when the stored status is consistent. Provisioning VMs are
+// left alone since their container may not exist yet.
+func reconciledStatus(status, containerState string) (string, bool) {
+	running := containerState == "RUNNING"
+	switch {
+	case status == constant.VirtualMachineStatusRunning && !running:
+		return constant.VirtualMachineStatusInactive, true
+	case status == constant.VirtualMachineStatusInactive && running:
+		return constant.VirtualMachineStatusRunning, true
+	default:
+		return "", false
+	}
+}
+
+// ReconcileVirtualMachines compares every VM's stored status with the state of
+// its container and corrects the mismatches, returning what it changed
+func (s *Service) ReconcileVirtualMachines(ctx context.Context, req *pb.ReconcileVirtualMachinesRequest, resp *pb.ReconcileVirtualMachinesResponse) error {
+
+	states, err := s.containerStates(ctx)
+	if err != nil {
+		response.ErrorInternal(resp)
+		return err
+	}
+
+	vms, err := s.VirtualMachineRepo.ListSummaries(ctx, database.VirtualMachineListFilter{})
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+
+	corrections := []*pb.VirtualMachineCorrection{}
+	for _, vm := range vms {
+		status, ok := reconciledStatus(vm.Status, states[vm.Name])
+		if !ok {
+			continue
+		}
+
+		updated, err := s.VirtualMachineRepo.UpdateStatusFrom(ctx, vm.Id, vm.Status, status)
+		if err != nil {
+			response.ErrorDbVirtualMachine(resp)
+			return err
+		}
+		if !updated {
+			// The status changed since it was listed; leave it to the next run
+			continue
+		}
+
+		logger.FromContext(ctx).Info("Reconciled virtual machine ", vm.Id, " from ", vm.Status, " to ", status)
+		corrections = append(corrections, &pb.VirtualMachineCorrection{
+			Id:             vm.Id,
+			Name:           vm.Name,
+			FromStatus:     vm.Status,
+			ToStatus:       status,
+			ContainerState: states[vm.Name],
+		})
+	}
+
+	resp.Corrections = corrections
+	response.Success(resp)
+	return nil
+}
@@ -0,0 +1,73 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+func TestReconcileVirtualMachines(t *testing.T) {
+	s := newTestService(t)
+	s.ContainerStates = func(context.Context) (map[string]string, error) {
+		return map[string]string{
+			"stopped":      "STOPPED",
+			"started":      "RUNNING",
+			"running":      "RUNNING",
+			"provisioning": "STOPPED",
+		}, nil
+	}
+	want := map[string]string{
+		// Running in the database, but its container is stopped or gone
+		"stopped": constant.VirtualMachineStatusInactive,
+		"missing": constant.VirtualMachineStatusInactive,
+		// Inactive in the database, but its container is running
+		"started":      constant.VirtualMachineStatusRunning,
+		"running":      constant.VirtualMachineStatusRunning,
+		"provisioning": constant.VirtualMachineStatusProvisioning,
+	}
+	vms := map[string]int32{}
+	for name, status := range map[string]string{
+		"stopped":      constant.VirtualMachineStatusRunning,
+		"missing":      constant.VirtualMachineStatusRunning,
+		"started":      constant.VirtualMachineStatusInactive,
+		"running":      constant.VirtualMachineStatusRunning,
+		"provisioning": constant.VirtualMachineStatusProvisioning,
+	} {
+		vms[name] = createVM(t, s, name, status).Id
+	}
+
+	resp := &pb.ReconcileVirtualMachinesResponse{Base: &core.BaseResponse{}}
+	if err := s.ReconcileVirtualMachines(context.Background(), &pb.ReconcileVirtualMachinesRequest{}, resp); err != nil {
+		t.Fatalf("ReconcileVirtualMachines: %v", err)
+	}
+	if resp.Base.Code != "00" {
+		t.Fatalf("code = %s, want 00", resp.Base.Code)
+	}
+
+	corrected := map[string]*pb.VirtualMachineCorrection{}
+	for _, correction := range resp.Corrections {
+		corrected[correction.Name] = correction
+	}
+	if len(corrected) != 3 {
+		t.Errorf("corrected %d VMs, want 3", len(corrected))
+	}
+	if c := corrected["stopped"]; c == nil || c.FromStatus != constant.VirtualMachineStatusRunning || c.ContainerState != "STOPPED" {
+		t.Errorf("stopped correction = %v", c)
+	}
+	if c := corrected["started"]; c == nil || c.FromStatus != constant.VirtualMachineStatusInactive || c.ContainerState != "RUNNING" {
+		t.Errorf("started correction = %v", c)
+	}
+
+	for name, status := range want {
+		stored, err := s.VirtualMachineRepo.Get(context.Background(), vms[name])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != status {
+			t.Errorf("%s status = %s, want %s", name, stored.Status, status)
+		}
+	}
+}
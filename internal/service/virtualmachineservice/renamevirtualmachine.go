@@ -0,0 +1,69 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/model/response"
+	"github.com/cynxees/ra-server/sandbox/images"
+)
+
+func (s *Service) RenameVirtualMachine(ctx context.Context, req *pb.RenameVirtualMachineRequest, resp *pb.VirtualMachineResponse) error {
+
+	if err := images.ValidateContainerName(req.NewName); err != nil {
+		response.ErrorValidation(resp)
+		return err
+	}
+
+	vm, err := s.VirtualMachineRepo.Get(ctx, req.Id)
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+	if vm == nil {
+		response.ErrorNotFound(resp)
+		return fmt.Errorf("virtual machine %d not found", req.Id)
+	}
+	if vm.Status == constant.VirtualMachineStatusRunning || vm.Status == constant.VirtualMachineStatusProvisioning {
+		response.ErrorNotAllowed(resp)
+		return fmt.Errorf("virtual machine %d is %s; stop it before renaming", vm.Id, vm.Status)
+	}
+	if vm.Name == req.NewName {
+		resp.Data = vm.Response()
+		response.Success(resp)
+		return nil
+	}
+
+	taken, err := s.VirtualMachineRepo.NameTaken(ctx, req.NewName, vm.Id)
+	if err != nil {
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+	if taken {
+		response.ErrorAlreadyExists(resp)
+		return fmt.Errorf("virtual machine name %q is already taken", req.NewName)
+	}
+
+	if err := s.renameContainer(ctx, vm.Name, req.NewName); err != nil {
+		response.ErrorInternal(resp)
+		return err
+	}
+	if err := s.VirtualMachineRepo.UpdateName(ctx, vm.Id, req.NewName); err != nil {
+		// Put the container back so it still matches the stored name
+		if rollbackErr := s.renameContainer(ctx, req.NewName, vm.Name); rollbackErr != nil {
+			logger.FromContext(ctx).Error("failed to restore container name for virtual machine ", vm.Id, ": ", rollbackErr)
+			err = errors.Join(err, rollbackErr)
+		}
+		response.ErrorDbVirtualMachine(resp)
+		return err
+	}
+
+	vm.Name = req.NewName
+	resp.Data = vm.Response()
+	response.Success(resp)
+	return nil
+}
@@ -0,0 +1,78 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+)
+
+func renameVM(s *Service, id int32, newName string) (*pb.VirtualMachineResponse, error) {
+	resp := &pb.VirtualMachineResponse{Base: &core.BaseResponse{}}
+	err := s.RenameVirtualMachine(context.Background(), &pb.RenameVirtualMachineRequest{Id: id, NewName: newName}, resp)
+	return resp, err
+}
+
+func TestRenameVirtualMachine(t *testing.T) {
+	s := newTestService(t)
+	var renamed [2]string
+	s.RenameContainer = func(_ context.Context, oldName, newName string) error {
+		renamed = [2]string{oldName, newName}
+		return nil
+	}
+	vm := createVM(t, s, "vm-a", constant.VirtualMachineStatusInactive)
+
+	resp, err := renameVM(s, vm.Id, "vm-b")
+	if err != nil || resp.Base.Code != "00" {
+		t.Fatalf("RenameVirtualMachine() = %s, %v", resp.Base.Code, err)
+	}
+	if renamed != [2]string{"vm-a", "vm-b"} {
+		t.Errorf("container renamed %v, want vm-a to vm-b", renamed)
+	}
+	stored, err := s.VirtualMachineRepo.Get(context.Background(), vm.Id)
+	if err != nil || stored.Name != "vm-b" {
+		t.Errorf("stored name = %q, %v, want vm-b", stored.Name, err)
+	}
+}
+
+func TestRenameVirtualMachineRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		newName  string
+		wantCode string
+	}{
+		{name: "name taken", status: constant.VirtualMachineStatusInactive, newName: "vm-taken", wantCode: "AE"},
+		{name: "running", status: constant.VirtualMachineStatusRunning, newName: "vm-free", wantCode: "NA"},
+		{name: "provisioning", status: constant.VirtualMachineStatusProvisioning, newName: "vm-free", wantCode: "NA"},
+		{name: "invalid name", status: constant.VirtualMachineStatusInactive, newName: "../vm", wantCode: "VE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			vm := createVM(t, s, "vm-a", tt.status)
+			createVM(t, s, "vm-taken", constant.VirtualMachineStatusInactive)
+
+			resp, err := renameVM(s, vm.Id, tt.newName)
+			if err == nil || resp.Base.Code != tt.wantCode {
+				t.Fatalf("RenameVirtualMachine() = %s, %v, want %s", resp.Base.Code, err, tt.wantCode)
+			}
+			stored, err := s.VirtualMachineRepo.Get(context.Background(), vm.Id)
+			if err != nil || stored.Name != "vm-a" {
+				t.Errorf("stored name = %q, %v, want it unchanged", stored.Name, err)
+			}
+		})
+	}
+}
+
+func TestRenameVirtualMachineNotFound(t *testing.T) {
+	s := newTestService(t)
+
+	resp, err := renameVM(s, 404, "vm-b")
+	if err == nil || resp.Base.Code != "NF" {
+		t.Errorf("RenameVirtualMachine() = %s, %v, want NF", resp.Base.Code, err)
+	}
+}
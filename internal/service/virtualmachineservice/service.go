@@ -1,9 +1,38 @@
 package virtualmachineservice
 
 import (
+	"context"
+
 	"github.com/cynxees/ra-server/internal/repository/database"
+	"github.com/cynxees/ra-server/sandbox/images"
 )
 
 type Service struct {
 	VirtualMachineRepo *database.VirtualMachineRepo
+	VMLabelRepo        *database.VMLabelRepo
+	// RenameContainer, StopContainer and ContainerStates default to their images counterparts when nil
+	RenameContainer func(ctx context.Context, oldName, newName string) error
+	StopContainer   func(ctx context.Context, name string) error
+	ContainerStates func(ctx context.Context) (map[string]string, error)
+}
+
+func (s *Service) renameContainer(ctx context.Context, oldName, newName string) error {
+	if s.RenameContainer != nil {
+		return s.RenameContainer(ctx, oldName, newName)
+	}
+	return images.RenameContainer(ctx, oldName, newName)
+}
+
+func (s *Service) stopContainer(ctx context.Context, name string) error {
+	if s.StopContainer != nil {
+		return s.StopContainer(ctx, name)
+	}
+	return images.StopContainer(ctx, name)
+}
+
+func (s *Service) containerStates(ctx context.Context) (map[string]string, error) {
+	if s.ContainerStates != nil {
+		return s.ContainerStates(ctx)
+	}
+	return images.ContainerStates(ctx)
 }
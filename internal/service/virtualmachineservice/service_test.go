@@ -0,0 +1,84 @@
+package virtualmachineservice
+
+import (
+	"context"
+	"testing"
+
+	core "github.com/cynxees/cynx-core/proto/gen"
+	coreContext "github.com/cynxees/cynx-core/src/context"
+	pb "github.com/cynxees/ra-server/api/proto/gen/ra"
+	"github.com/cynxees/ra-server/internal/constant"
+	"github.com/cynxees/ra-server/internal/logger"
+	"github.com/cynxees/ra-server/internal/model/entity"
+	"github.com/cynxees/ra-server/internal/repository/database"
+	"github.com/cynxees/ra-server/internal/testutil"
+)
+
+// newTestService returns a Service on a fresh test database whose container
+// hooks fail the test unless a test replaces them. Log lines are dropped.
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	previous := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+
+	db := testutil.NewDB(t)
+	return &Service{
+		VirtualMachineRepo: database.NewVirtualMachineRepo(db),
+		VMLabelRepo:        database.NewVMLabelRepo(db),
+		RenameContainer: func(context.Context, string, string) error {
+			t.Fatal("unexpected container rename")
+			return nil
+		},
+		StopContainer: func(context.Context, string) error {
+			t.Fatal("unexpected container stop")
+			return nil
+		},
+		ContainerStates: func(context.Context) (map[string]string, error) {
+			t.Fatal("unexpected container state lookup")
+			return nil, nil
+		},
+	}
+}
+
+// createVM stores a VM with the given name and status and returns it
+func createVM(t *testing.T, s *Service, name, status string) *entity.VirtualMachine {
+	t.Helper()
+	vm := &entity.VirtualMachine{Name: name, Status: status, Type: "lxc", UserID: 1}
+	if err := s.VirtualMachineRepo.DB.Create(vm).Error; err != nil {
+		t.Fatalf("create vm: %v", err)
+	}
+	return vm
+}
+
+// requestContext sets up ctx the way the gRPC context interceptor does
+func requestContext(base *core.BaseRequest) context.Context {
+	return coreContext.SetupContext(context.Background(), &pb.DeleteVirtualMachineRequest{Base: base})
+}
+
+func TestServiceLogsCarryRequestFields(t *testing.T) {
+	s := newTestService(t)
+	var lines []string
+	previous := logger.SetWriter(func(ctx context.Context, _ logger.Level, _ string) {
+		lines = append(lines, logger.RequestFields(ctx))
+	})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+
+	s.StopContainer = func(context.Context, string) error { return nil }
+	vm := createVM(t, s, "vm-a", constant.VirtualMachineStatusRunning)
+
+	userID := int32(42)
+	ctx := requestContext(&core.BaseRequest{RequestId: "req-9", UserId: &userID})
+	resp := &pb.VirtualMachineResponse{Base: &core.BaseResponse{}}
+	if err := s.DeleteVirtualMachine(ctx, &pb.DeleteVirtualMachineRequest{Id: vm.Id, Force: true}, resp); err != nil {
+		t.Fatalf("DeleteVirtualMachine: %v", err)
+	}
+
+	if len(lines) == 0 {
+		t.Fatal("service logged nothing")
+	}
+	for _, fields := range lines {
+		if fields != "request_id=req-9 user_id=42" {
+			t.Errorf("line logged with fields %q, want the request's", fields)
+		}
+	}
+}
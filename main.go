@@ -2,25 +2,39 @@ package main
 
 import (
 	"context"
+	"flag"
 	"github.com/cynxees/cynx-core/src/logger"
 	"github.com/cynxees/ra-server/internal/app"
+	ralogger "github.com/cynxees/ra-server/internal/logger"
 	"github.com/cynxees/ra-server/sandbox/images"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
-func main() {
+// shutdownTimeout bounds how long in-flight requests get to finish on shutdown
+const shutdownTimeout = 30 * time.Second
 
-	// Switch to QEMU/KVM images instead of LXC due to unprivileged container restrictions
-	images.RunUbuntuContainer()
-	images.RunJava8Container()
-	panic("done")
+// buildImagesFlag builds the LXC base images and exits instead of serving
+var buildImagesFlag = flag.Bool("build-images", false, "build the LXC base images and exit")
 
+func main() {
+	flag.Parse()
 	log.Println("Starting ra")
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer func() {
 		cancel()
 	}()
 
+	if *buildImagesFlag {
+		if err := buildImages(ctx); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	log.Println("Initializing App")
 	application, err := app.NewApp(ctx)
 	if err != nil {
@@ -34,9 +48,36 @@ func main() {
 	}
 
 	logger.Info(ctx, "Starting servers")
-	if err := servers.Start(ctx); err != nil {
-		panic(err)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- servers.Start(ctx)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if err != nil {
+			panic(err)
+		}
+	case <-ctx.Done():
+	}
+
+	logger.Info(ctx, "Shutting down")
+	stopCtx, stopCancel := context.WithTimeout(context.WithoutCancel(ctx), shutdownTimeout)
+	defer stopCancel()
+	if _, err := servers.Stop(stopCtx); err != nil {
+		log.Println("Shutdown incomplete:", err)
 	}
+}
 
-	<-ctx.Done()
+// buildImages builds the LXC base images without initializing the app, which
+// needs a database, so build messages go to stdout
+func buildImages(ctx context.Context) error {
+	ralogger.SetWriter(ralogger.StdoutWriter)
+
+	// Switch to QEMU/KVM images instead of LXC due to unprivileged container restrictions
+	if _, err := images.RunUbuntuContainer(ctx, images.BuildOptions{}); err != nil {
+		return err
+	}
+	_, err := images.RunJava8Container(ctx, images.BuildOptions{})
+	return err
 }
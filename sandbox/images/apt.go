@@ -0,0 +1,52 @@
+package images
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ubuntuArchivePattern matches the stock Ubuntu archive URLs in sources.list
+var ubuntuArchivePattern = regexp.MustCompile(`https?://([a-z]{2}\.)?(archive|security)\.ubuntu\.com/ubuntu/?`)
+
+// validateAptMirror accepts an empty mirror or an http(s) URL
+func validateAptMirror(mirror string) error {
+	if mirror == "" {
+		return nil
+	}
+	u, err := url.Parse(mirror)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid apt mirror %q: expected an http(s) URL", mirror)
+	}
+	return nil
+}
+
+// rewriteAptSources points the Ubuntu archive entries of a sources.list at mirror
+func rewriteAptSources(sources, mirror string) string {
+	return ubuntuArchivePattern.ReplaceAllLiteralString(sources, strings.TrimSuffix(mirror, "/")+"/")
+}
+
+// configureAptMirror rewrites the rootfs sources.list to use AptMirror before any apt-get runs
+func (l *LXCBuilder) configureAptMirror(rootfsPath string) error {
+	if l.AptMirror == "" {
+		return nil
+	}
+	if err := validateAptMirror(l.AptMirror); err != nil {
+		return err
+	}
+
+	sourcesPath := filepath.Join(rootfsPath, "etc", "apt", "sources.list")
+	content, err := os.ReadFile(sourcesPath)
+	if err != nil {
+		return withStep("read apt sources", err)
+	}
+
+	l.log("🪞 Using apt mirror: %s", l.AptMirror)
+	if err := os.WriteFile(sourcesPath, []byte(rewriteAptSources(string(content), l.AptMirror)), 0644); err != nil {
+		return withStep("write apt sources", err)
+	}
+	return nil
+}
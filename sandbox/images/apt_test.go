@@ -0,0 +1,90 @@
+package images
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const jammySources = `# See http://help.ubuntu.com/community/UpgradeNotes for how to upgrade to
+# newer versions of the distribution.
+deb http://archive.ubuntu.com/ubuntu jammy main restricted
+deb http://us.archive.ubuntu.com/ubuntu/ jammy-updates main restricted
+# deb-src http://archive.ubuntu.com/ubuntu jammy universe
+deb https://security.ubuntu.com/ubuntu jammy-security main restricted
+deb http://ppa.launchpadcontent.net/deadsnakes/ppa/ubuntu jammy main
+`
+
+func TestRewriteAptSources(t *testing.T) {
+	want := `# See http://help.ubuntu.com/community/UpgradeNotes for how to upgrade to
+# newer versions of the distribution.
+deb http://mirror.example.com/ubuntu/ jammy main restricted
+deb http://mirror.example.com/ubuntu/ jammy-updates main restricted
+# deb-src http://mirror.example.com/ubuntu/ jammy universe
+deb http://mirror.example.com/ubuntu/ jammy-security main restricted
+deb http://ppa.launchpadcontent.net/deadsnakes/ppa/ubuntu jammy main
+`
+	// With or without a trailing slash the mirror gets exactly one
+	for _, mirror := range []string{"http://mirror.example.com/ubuntu", "http://mirror.example.com/ubuntu/"} {
+		if got := rewriteAptSources(jammySources, mirror); got != want {
+			t.Errorf("rewriteAptSources(%s) =\n%s\nwant\n%s", mirror, got, want)
+		}
+	}
+}
+
+func TestConfigureAptMirror(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.AptMirror = "https://mirror.example.com/ubuntu"
+	rootfs := filepath.Join(builder.ContainerDir, "vm-a", "rootfs")
+	sourcesPath := filepath.Join(rootfs, "etc", "apt", "sources.list")
+	writeRootfs(t, rootfs, "etc/apt/sources.list")
+	if err := os.WriteFile(sourcesPath, []byte(jammySources), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := builder.configureAptMirror(rootfs); err != nil {
+		t.Fatalf("configureAptMirror() error = %v", err)
+	}
+	content, err := os.ReadFile(sourcesPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "archive.ubuntu.com") || strings.Contains(string(content), "security.ubuntu.com") {
+		t.Errorf("sources.list still uses the Ubuntu archive:\n%s", content)
+	}
+	if n := strings.Count(string(content), "https://mirror.example.com/ubuntu/ jammy"); n != 4 {
+		t.Errorf("sources.list has %d mirror entries, want 4:\n%s", n, content)
+	}
+}
+
+func TestConfigureAptMirrorRejects(t *testing.T) {
+	tests := []struct {
+		name      string
+		mirror    string
+		wantError string
+	}{
+		{name: "not a url", mirror: "mirror.example.com", wantError: "expected an http(s) URL"},
+		{name: "ftp", mirror: "ftp://mirror.example.com/ubuntu", wantError: "expected an http(s) URL"},
+		{name: "no sources.list", mirror: "http://mirror.example.com/ubuntu", wantError: "failed to read apt sources"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newTestBuilder(t)
+			builder.AptMirror = tt.mirror
+
+			err := builder.configureAptMirror(filepath.Join(builder.ContainerDir, "vm-a", "rootfs"))
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("configureAptMirror() error = %v, want %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestConfigureAptMirrorUnset(t *testing.T) {
+	builder := newTestBuilder(t)
+	// No mirror leaves the rootfs alone, even without a sources.list
+	if err := builder.configureAptMirror(filepath.Join(builder.ContainerDir, "missing")); err != nil {
+		t.Errorf("configureAptMirror() error = %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+)
+
+// LayerSpec names a layer image to build and the options for its build. With
+// no Parent it builds the image registered under Name; otherwise it describes
+// a new layer on the registered image Parent, provisioned by Options.Steps,
+// and LayerPaths are the rootfs-relative paths those steps add.
+type LayerSpec struct {
+	Name       string
+	Parent     string
+	LayerPaths []string
+	Options    BuildOptions
+}
+
+// containerBuild returns how to build the layer s describes
+func (s LayerSpec) containerBuild() (containerBuild, error) {
+	registered, ok := containerBuilds[s.Name]
+	if s.Parent == "" {
+		switch {
+		case !ok:
+			return containerBuild{}, fmt.Errorf("unknown container image %q", s.Name)
+		case registered.parent == "":
+			return containerBuild{}, fmt.Errorf("%s is a base image, not a layer", s.Name)
+		}
+		return registered, nil
+	}
+
+	switch {
+	case ok:
+		return containerBuild{}, fmt.Errorf("%s is a registered image, leave Parent unset to build it", s.Name)
+	case !HasContainerBuild(s.Parent):
+		return containerBuild{}, fmt.Errorf("unknown parent image %q for layer %s", s.Parent, s.Name)
+	case len(s.LayerPaths) == 0:
+		return containerBuild{}, fmt.Errorf("layer %s declares no layer paths", s.Name)
+	case len(s.Options.Steps) == 0:
+		return containerBuild{}, fmt.Errorf("layer %s has no steps to provision it", s.Name)
+	}
+	if err := ValidateContainerName(s.Name); err != nil {
+		return containerBuild{}, err
+	}
+	return containerBuild{build: buildStepsLayer, parent: s.Parent, layerPaths: s.LayerPaths}, nil
+}
+
+// buildStepsLayer creates containerName from parentLayer and provisions it
+// with l.Steps, for layers a LayerSpec describes rather than registers
+func buildStepsLayer(l *LXCBuilder, containerName, parentLayer string) (err error) {
+	defer func() {
+		if err != nil {
+			l.discardFailedBuild(containerName)
+		}
+	}()
+
+	l.log("🪜 FROM %s - Creating layer %s...", parentLayer, containerName)
+	if err := l.createLayerFromParent(containerName, parentLayer); err != nil {
+		return fmt.Errorf("failed to create layer from parent: %w", err)
+	}
+	rootfsPath := filepath.Join(l.ContainerDir, containerName, "rootfs")
+	if err := l.configureAptMirror(rootfsPath); err != nil {
+		return err
+	}
+
+	l.log("🚀 Starting container for provisioning...")
+	if err := l.runCommand("lxc-start", "-n", containerName, "-P", l.ContainerDir, "-d"); err != nil {
+		return withStep("start container", err)
+	}
+	defer func() {
+		l.log("⏹️ Stopping container...")
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		l.cleanupMounts(rootfsPath)
+	}()
+
+	l.log("⏳ Waiting for container to be ready...")
+	if err := l.waitForRunning(containerName); err != nil {
+		return err
+	}
+	if err := l.runSteps(containerName); err != nil {
+		return err
+	}
+
+	l.log("✅ Layer created successfully: %s", containerName)
+	return nil
+}
+
+// LayerResult is the outcome of building one LayerSpec
+type LayerResult struct {
+	Result *BuildResult
+	Err    error
+	Name   string
+}
+
+// BuildLayers builds the given layers with at most concurrency running at once,
+// after making sure each parent image exists. Parents that are missing are
+// built first with the options of the first layer that needs them. Results are
+// returned in spec order and a failed layer does not stop the others; layers
+// on a parent that failed to build all report that failure, and a name used
+// more than once is only built for its first spec. Every build still takes a
+// slot from SetMaxConcurrentBuilds, so raise that limit to match.
+func BuildLayers(ctx context.Context, specs []LayerSpec, concurrency int) []LayerResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([]LayerResult, len(specs))
+	builds := make([]containerBuild, len(specs))
+	seen := map[string]bool{}
+	parentErrs := map[string]error{}
+	var pending []int
+	for i, spec := range specs {
+		results[i].Name = spec.Name
+		if seen[spec.Name] {
+			results[i].Err = fmt.Errorf("duplicate layer %q", spec.Name)
+			continue
+		}
+		seen[spec.Name] = true
+
+		build, err := spec.containerBuild()
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		builds[i] = build
+
+		parentErr, built := parentErrs[build.parent]
+		if !built {
+			parentErr = ensureParentImage(ctx, build.parent, spec.Options)
+			parentErrs[build.parent] = parentErr
+		}
+		if parentErr != nil {
+			results[i].Err = fmt.Errorf("parent %s: %w", build.parent, parentErr)
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(concurrency, len(pending)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].Result, results[i].Err = runContainerBuild(ctx, specs[i].Name, builds[i], specs[i].Options)
+			}
+		}()
+	}
+	for _, i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// ensureParentImage builds the parent image unless a build of it with opts already exists
+func ensureParentImage(ctx context.Context, parent string, opts BuildOptions) error {
+	_, containerDir, err := buildDirs()
+	if err != nil {
+		return err
+	}
+	if _, found := latestBuildName(containerDir, parent, opts.buildSpec("", nil)); found {
+		return nil
+	}
+
+	_, err = BuildContainer(ctx, parent, opts)
+	return err
+}
@@ -0,0 +1,109 @@
+package images
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildLayersRejectsInvalidSpecs(t *testing.T) {
+	t.Chdir(t.TempDir())
+	opts := BuildOptions{Steps: []RunStep{{Name: "install", Args: []string{"apt-get", "install", "-y", "tool"}}}}
+
+	specs := []LayerSpec{
+		{Name: "python3", Parent: "ubuntu-base", LayerPaths: []string{"usr/bin/python3"}},
+		{Name: "python3", Parent: "ubuntu-base", LayerPaths: []string{"usr/bin/python3"}, Options: opts},
+		{Name: "node", Parent: "debian-base", LayerPaths: []string{"usr/bin/node"}, Options: opts},
+		{Name: "golang", Parent: "ubuntu-base", Options: opts},
+		{Name: "../escape", Parent: "ubuntu-base", LayerPaths: []string{"usr/local/go"}, Options: opts},
+		{Name: "ubuntu-java8", Parent: "ubuntu-base", LayerPaths: []string{"usr/lib/jvm"}, Options: opts},
+		{Name: "ubuntu-base"},
+		{Name: "ruby"},
+	}
+	wantErrs := []string{
+		"no steps",
+		"duplicate layer",
+		"unknown parent image",
+		"no layer paths",
+		"invalid container name",
+		"registered image",
+		"base image",
+		"unknown container image",
+	}
+
+	results := BuildLayers(context.Background(), specs, 2)
+	if len(results) != len(specs) {
+		t.Fatalf("got %d results, want %d", len(results), len(specs))
+	}
+	for i, result := range results {
+		if result.Name != specs[i].Name {
+			t.Errorf("result %d name = %q, want %q", i, result.Name, specs[i].Name)
+		}
+		if result.Err == nil || !strings.Contains(result.Err.Error(), wantErrs[i]) {
+			t.Errorf("result %d (%s) error = %v, want one containing %q", i, specs[i].Name, result.Err, wantErrs[i])
+		}
+		if result.Result != nil {
+			t.Errorf("result %d (%s) was built", i, specs[i].Name)
+		}
+	}
+}
+
+func TestBuildLayersProvisionsLayersWithSteps(t *testing.T) {
+	stubBuildEnvironment(t)
+	_, containerDir, err := buildDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An existing base build, so BuildLayers does not build it first
+	base := buildName("ubuntu-base", BuildOptions{}.buildSpec("", nil))
+	writeRootfs(t, filepath.Join(containerDir, base), "config", "rootfs/bin/bash", "rootfs/etc/os-release")
+
+	// Each install step puts its tool where the layer declares it
+	installs := map[string]string{"python3": "usr/bin/python3", "nodejs": "usr/bin/node"}
+	commands := stubCommands(t, func(ctx context.Context, name string, args []string) *exec.Cmd {
+		switch name {
+		case "cp", "tar":
+			return exec.CommandContext(ctx, name, args...)
+		case "lxc-info":
+			return fakeOutput(ctx, "State:          RUNNING\n", 0)
+		case "lxc-attach":
+			rootfs := filepath.Join(containerDir, args[1], "rootfs")
+			tool := args[len(args)-1]
+			writeRootfs(t, rootfs, installs[tool])
+		}
+		return nil
+	})
+
+	layer := func(name, tool string) LayerSpec {
+		return LayerSpec{
+			Name:       name,
+			Parent:     "ubuntu-base",
+			LayerPaths: []string{installs[tool]},
+			Options:    BuildOptions{Steps: []RunStep{{Name: "install " + tool, Args: []string{"apt-get", "install", "-y", tool}}}},
+		}
+	}
+	specs := []LayerSpec{layer("python3", "python3"), layer("node", "nodejs")}
+
+	results := BuildLayers(context.Background(), specs, 2)
+	for i, result := range results {
+		if result.Err != nil {
+			t.Fatalf("layer %s: %v", specs[i].Name, result.Err)
+		}
+		if !strings.HasPrefix(result.Result.ContainerName, specs[i].Name+"-") {
+			t.Errorf("layer %s container = %q", specs[i].Name, result.Result.ContainerName)
+		}
+		entries := archiveEntries(t, result.Result.ArchivePath)
+		if !slices.Contains(entries, specs[i].LayerPaths[0]) {
+			t.Errorf("layer %s archive = %v, want it to hold %s", specs[i].Name, entries, specs[i].LayerPaths[0])
+		}
+	}
+
+	for _, command := range commands.commands() {
+		if strings.HasPrefix(command, "lxc-create") {
+			t.Errorf("built the existing base image: %s", command)
+		}
+	}
+}
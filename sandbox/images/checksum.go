@@ -0,0 +1,44 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// checksumSuffix is appended to an archive's path to name its checksum file
+const checksumSuffix = ".sha256"
+
+// fileSHA256 streams the file through SHA256 and returns the hex digest
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// writeChecksum writes <archive>.sha256 in sha256sum format next to the archive
+// and returns the checksum, so the file can be checked with sha256sum -c
+func (l *LXCBuilder) writeChecksum(archivePath string) (string, error) {
+	checksum, err := fileSHA256(archivePath)
+	if err != nil {
+		return "", withStep("checksum archive", err)
+	}
+
+	line := fmt.Sprintf("%s  %s\n", checksum, filepath.Base(archivePath))
+	if err := os.WriteFile(archivePath+checksumSuffix, []byte(line), 0644); err != nil {
+		return "", withStep("write archive checksum", err)
+	}
+	l.log("🔐 SHA256: %s", checksum)
+	return checksum, nil
+}
@@ -0,0 +1,35 @@
+package images
+
+import "time"
+
+// Clock abstracts the current time and waiting so timestamped names, metadata
+// and readiness timeouts can be made deterministic
+type Clock interface {
+	Now() time.Time
+	// After sends on the returned channel once d has passed on this clock
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock reads the system time
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// FixedClock always reports the same instant; its waits still take real time
+type FixedClock struct {
+	Time time.Time
+}
+
+func (c FixedClock) Now() time.Time {
+	return c.Time
+}
+
+func (c FixedClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,327 @@
+package images
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cynxees/ra-server/internal/helper"
+)
+
+// ContainerInfo describes a built container and its current state
+type ContainerInfo struct {
+	Name  string
+	State string
+	IP    string
+}
+
+// ListContainers scans the container directory and queries lxc-info for each container found
+func (l *LXCBuilder) ListContainers() ([]ContainerInfo, error) {
+	entries, err := os.ReadDir(l.ContainerDir)
+	if err != nil {
+		return nil, err
+	}
+
+	containers := []ContainerInfo{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(l.ContainerDir, entry.Name(), "config")); err != nil {
+			continue
+		}
+
+		info := ContainerInfo{Name: entry.Name(), State: "UNKNOWN"}
+		if output, err := l.commandOutput("lxc-info", "-n", entry.Name(), "-P", l.ContainerDir); err == nil {
+			info = parseLXCInfo(output)
+			if info.Name == "" {
+				info.Name = entry.Name()
+			}
+		}
+		containers = append(containers, info)
+	}
+
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
+	return containers, nil
+}
+
+// parseLXCInfo extracts name, state and first IP from lxc-info output
+func parseLXCInfo(output string) ContainerInfo {
+	var info ContainerInfo
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.TrimSpace(key) {
+		case "Name":
+			info.Name = value
+		case "State":
+			info.State = value
+		case "IP":
+			if info.IP == "" {
+				info.IP = value
+			}
+		}
+	}
+
+	return info
+}
+
+// commandOutput executes a query command and returns its output without echoing it
+func (l *LXCBuilder) commandOutput(name string, args ...string) (string, error) {
+	command := strings.TrimSpace(name + " " + strings.Join(args, " "))
+
+	cmd := execCommand(l.ctx, name, args...)
+	killOnCancel(cmd)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if ctxErr := l.ctx.Err(); ctxErr != nil {
+			return string(output), ctxErr
+		}
+		return string(output), newProvisionError(command, output, err)
+	}
+	return string(output), nil
+}
+
+// ValidateContainerName rejects names that cannot safely be used as a container directory
+func ValidateContainerName(name string) error {
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) || strings.ContainsAny(name, "*?[ \t\n") {
+		return fmt.Errorf("invalid container name %q", name)
+	}
+	return nil
+}
+
+// StopContainer stops a running container; it is a no-op when the container was never built
+func (l *LXCBuilder) StopContainer(name string) error {
+	if err := ValidateContainerName(name); err != nil {
+		return err
+	}
+	containerPath, err := helper.SafeJoin(l.ContainerDir, name)
+	if err != nil {
+		return err
+	}
+	if !l.dirExists(containerPath) {
+		return nil
+	}
+
+	state, err := containerState(l, name)
+	if err == nil && state == "STOPPED" {
+		return nil
+	}
+	l.log("⏹️ Stopping container: %s", name)
+	if err := l.runCommand("lxc-stop", "-n", name, "-P", l.ContainerDir); err != nil {
+		return withStep("stop container", err)
+	}
+	return nil
+}
+
+// DestroyContainer stops and destroys a container and removes its exported
+// archives and metadata. Its -latest symlinks are removed once they dangle. It
+// is a no-op for containers and archives that are already gone.
+func (l *LXCBuilder) DestroyContainer(name string) error {
+	if err := ValidateContainerName(name); err != nil {
+		return err
+	}
+
+	containerPath, err := helper.SafeJoin(l.ContainerDir, name)
+	if err != nil {
+		return err
+	}
+	if l.dirExists(containerPath) {
+		l.log("🗑️ Destroying container: %s", name)
+		l.runCommand("lxc-stop", "-n", name, "-P", l.ContainerDir)
+		l.unmountOverlay(containerPath)
+		if err := l.runCommand("lxc-destroy", "-n", name, "-P", l.ContainerDir); err != nil && l.dirExists(containerPath) {
+			return withStep("destroy container", err)
+		}
+	}
+
+	patterns := []string{
+		fmt.Sprintf("lxc-%s-[0-9]*.tar.gz", name),
+		fmt.Sprintf("lxc-%s-[0-9]*.tar.gz%s", name, checksumSuffix),
+		fmt.Sprintf("lxc-%s-layer-[0-9]*.tar.gz", name),
+		fmt.Sprintf("lxc-%s-layer-[0-9]*.tar.gz%s", name, checksumSuffix),
+		fmt.Sprintf("%s-layer.json", name),
+	}
+	prefixes := []string{name}
+	// Base images are exported under their spec rather than the container name
+	if build, ok := buildOf(name); ok && build.parent == "" {
+		patterns = append(patterns,
+			fmt.Sprintf("lxc-%s-[0-9]*.tar.gz", l.Spec),
+			fmt.Sprintf("lxc-%s-[0-9]*.tar.gz%s", l.Spec, checksumSuffix),
+		)
+		prefixes = append(prefixes, l.Spec.String())
+	}
+
+	var artifacts []string
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(filepath.Join(l.WorkDir, pattern))
+		if err != nil {
+			return err
+		}
+		artifacts = append(artifacts, matches...)
+	}
+
+	for _, artifact := range artifacts {
+		if err := os.Remove(artifact); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", artifact, err)
+		}
+		l.log("🗑️ Removed: %s", artifact)
+	}
+
+	return l.removeDanglingSymlinks(prefixes)
+}
+
+// removeDanglingSymlinks deletes the lxc-<prefix>-latest.tar.gz and
+// lxc-<prefix>-layer-latest.tar.gz links whose target no longer exists, leaving
+// other containers' links alone
+func (l *LXCBuilder) removeDanglingSymlinks(prefixes []string) error {
+	for _, prefix := range prefixes {
+		for _, link := range []string{
+			fmt.Sprintf("lxc-%s-latest.tar.gz", prefix),
+			fmt.Sprintf("lxc-%s-layer-latest.tar.gz", prefix),
+		} {
+			if err := l.removeDanglingSymlink(filepath.Join(l.WorkDir, link)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// removeDanglingSymlink deletes linkPath when it is a symlink whose target no longer exists
+func (l *LXCBuilder) removeDanglingSymlink(linkPath string) error {
+	info, err := os.Lstat(linkPath)
+	if err != nil || info.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+	if _, err := os.Stat(linkPath); os.IsNotExist(err) {
+		if err := os.Remove(linkPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove symlink %s: %w", linkPath, err)
+		}
+		l.log("🔗 Removed dangling symlink: %s", linkPath)
+	}
+	return nil
+}
+
+// RenameContainer stops a container, moves its directory and rewrites the
+// name and rootfs path in its config. It is a no-op when the container was
+// never built.
+func (l *LXCBuilder) RenameContainer(oldName, newName string) error {
+	if err := ValidateContainerName(oldName); err != nil {
+		return err
+	}
+	if err := ValidateContainerName(newName); err != nil {
+		return err
+	}
+
+	oldPath, err := helper.SafeJoin(l.ContainerDir, oldName)
+	if err != nil {
+		return err
+	}
+	newPath, err := helper.SafeJoin(l.ContainerDir, newName)
+	if err != nil {
+		return err
+	}
+	if !l.dirExists(oldPath) {
+		return nil
+	}
+	if l.dirExists(newPath) {
+		return fmt.Errorf("container %q already exists", newName)
+	}
+
+	l.log("✏️ Renaming container %s to %s", oldName, newName)
+	l.runCommand("lxc-stop", "-n", oldName, "-P", l.ContainerDir)
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return withStep("move container directory", err)
+	}
+
+	configPath := filepath.Join(newPath, "config")
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return withStep("read container config", err)
+	}
+	if err := os.WriteFile(configPath, []byte(renameInConfig(string(content), oldPath, newPath, newName)), 0644); err != nil {
+		return withStep("rewrite container config", err)
+	}
+	return nil
+}
+
+// renameInConfig points lxc.uts.name and lxc.rootfs.path at the renamed container
+func renameInConfig(config, oldPath, newPath, newName string) string {
+	lines := strings.Split(config, "\n")
+	for i, line := range lines {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "lxc.uts.name":
+			lines[i] = "lxc.uts.name = " + newName
+		case "lxc.rootfs.path":
+			lines[i] = "lxc.rootfs.path = " + strings.Replace(strings.TrimSpace(value), oldPath, newPath, 1)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// RenameContainer renames a container in the default build directory
+func RenameContainer(ctx context.Context, oldName, newName string) error {
+	workDir, containerDir, err := buildDirs()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(containerDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	builder := newContainerBuilder(ctx, workDir, containerDir)
+	return builder.RenameContainer(oldName, newName)
+}
+
+// StopContainer stops a container in the default build directory
+func StopContainer(ctx context.Context, name string) error {
+	workDir, containerDir, err := buildDirs()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(containerDir); os.IsNotExist(err) {
+		return nil
+	}
+
+	builder := newContainerBuilder(ctx, workDir, containerDir)
+	return builder.StopContainer(name)
+}
+
+// ContainerStates maps each container in the default build directory to its
+// lxc-info state, e.g. RUNNING or STOPPED
+func ContainerStates(ctx context.Context) (map[string]string, error) {
+	workDir, containerDir, err := buildDirs()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(containerDir); os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+
+	builder := newContainerBuilder(ctx, workDir, containerDir)
+	containers, err := builder.ListContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	states := make(map[string]string, len(containers))
+	for _, container := range containers {
+		states[container.Name] = container.State
+	}
+	return states, nil
+}
@@ -0,0 +1,198 @@
+package images
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/cynxees/ra-server/internal/logger"
+)
+
+// newTestBuilder returns a builder on temporary directories that logs nowhere
+func newTestBuilder(t *testing.T) *LXCBuilder {
+	t.Helper()
+
+	previous := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+
+	builder := NewLXCBuilder(context.Background(), t.TempDir(), t.TempDir())
+	t.Cleanup(builder.Close)
+	return builder
+}
+
+func TestDestroyContainerRemovesOnlyItsSymlinks(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	archive := filepath.Join(builder.WorkDir, "lxc-java8-layer-1700000000.tar.gz")
+	if err := os.WriteFile(archive, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	links := map[string]string{
+		"lxc-java8-layer-latest.tar.gz": archive,
+		// Dangling, but owned by another container
+		"lxc-python3-layer-latest.tar.gz": "lxc-python3-layer-1700000000.tar.gz",
+		"unrelated-latest":                "missing",
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, filepath.Join(builder.WorkDir, link)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := builder.DestroyContainer("java8"); err != nil {
+		t.Fatalf("DestroyContainer() error = %v", err)
+	}
+
+	if _, err := os.Lstat(archive); !os.IsNotExist(err) {
+		t.Errorf("archive still exists: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(builder.WorkDir, "lxc-java8-layer-latest.tar.gz")); !os.IsNotExist(err) {
+		t.Errorf("java8 latest link still exists: %v", err)
+	}
+	for _, link := range []string{"lxc-python3-layer-latest.tar.gz", "unrelated-latest"} {
+		if _, err := os.Lstat(filepath.Join(builder.WorkDir, link)); err != nil {
+			t.Errorf("%s was removed: %v", link, err)
+		}
+	}
+}
+
+func TestDestroyContainerKeepsLiveSymlink(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	// A link still pointing at an archive is kept even when it belongs to the container
+	target := filepath.Join(t.TempDir(), "kept.tar.gz")
+	if err := os.WriteFile(target, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(builder.WorkDir, "lxc-java8-layer-latest.tar.gz")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := builder.DestroyContainer("java8"); err != nil {
+		t.Fatalf("DestroyContainer() error = %v", err)
+	}
+	if _, err := os.Lstat(link); err != nil {
+		t.Errorf("live link was removed: %v", err)
+	}
+}
+
+func TestContainerHelpersLeaveBuildLogsAlone(t *testing.T) {
+	t.Chdir(t.TempDir())
+	previous := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+
+	// Keeping a single log would make a build prune the existing one
+	maxLogs, maxBytes := logRetention()
+	SetLogRetention(1, 0)
+	t.Cleanup(func() { SetLogRetention(maxLogs, maxBytes) })
+
+	workDir, containerDir, err := buildDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(containerDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	existing := filepath.Join(workDir, "build-20240101-000000.log")
+	if err := os.WriteFile(existing, []byte("old build"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, err := ContainerStates(ctx); err != nil {
+		t.Errorf("ContainerStates() error = %v", err)
+	}
+	if err := StopContainer(ctx, "missing"); err != nil {
+		t.Errorf("StopContainer() error = %v", err)
+	}
+	if err := RenameContainer(ctx, "missing", "renamed"); err != nil {
+		t.Errorf("RenameContainer() error = %v", err)
+	}
+
+	logs, err := filepath.Glob(filepath.Join(workDir, "build-*.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0] != existing {
+		t.Errorf("build logs = %v, want only %s", logs, existing)
+	}
+}
+
+func TestParseLXCInfo(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   ContainerInfo
+	}{
+		{
+			name: "running",
+			output: `Name:           ubuntu-base-1a2b3c4d
+State:          RUNNING
+PID:            4121
+IP:             10.0.3.114
+IP:             fd42:5b1e:93c0:2a0f:216:3eff:fe6b:1c2d
+CPU use:        1.95 seconds
+Memory use:     38.52 MiB
+Link:           vethQ7Kd2L
+ TX bytes:      1.80 KiB
+`,
+			want: ContainerInfo{Name: "ubuntu-base-1a2b3c4d", State: "RUNNING", IP: "10.0.3.114"},
+		},
+		{
+			name: "stopped",
+			output: `Name:           ubuntu-java8-5e6f7a8b
+State:          STOPPED
+`,
+			want: ContainerInfo{Name: "ubuntu-java8-5e6f7a8b", State: "STOPPED"},
+		},
+		{
+			name:   "state only",
+			output: "State:          FROZEN\n",
+			want:   ContainerInfo{State: "FROZEN"},
+		},
+		{
+			name:   "no fields",
+			output: "lxc-info: ubuntu-base: tools/lxc_info.c: main: 407 Container is not defined\n\n",
+			want:   ContainerInfo{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseLXCInfo(tt.output); got != tt.want {
+				t.Errorf("parseLXCInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListContainersQueriesLXCInfo(t *testing.T) {
+	builder := newTestBuilder(t)
+	prepareDownloadedContainer(t, builder, "vm-b")
+	prepareDownloadedContainer(t, builder, "vm-a")
+	// Directories without a config are not containers
+	if err := os.Mkdir(filepath.Join(builder.ContainerDir, "scratch"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	stubCommands(t, func(ctx context.Context, name string, args []string) *exec.Cmd {
+		if args[1] == "vm-a" {
+			return fakeOutput(ctx, "Name: vm-a\nState: RUNNING\nIP: 10.0.3.5\n", 0)
+		}
+		return fakeOutput(ctx, "vm-b is not running", 1)
+	})
+
+	containers, err := builder.ListContainers()
+	if err != nil {
+		t.Fatalf("ListContainers() error = %v", err)
+	}
+	want := []ContainerInfo{
+		{Name: "vm-a", State: "RUNNING", IP: "10.0.3.5"},
+		{Name: "vm-b", State: "UNKNOWN"},
+	}
+	if !slices.Equal(containers, want) {
+		t.Errorf("ListContainers() = %+v, want %+v", containers, want)
+	}
+}
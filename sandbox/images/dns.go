@@ -0,0 +1,38 @@
+package images
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// defaultDNSServers are public resolvers written into the container during setup
+var defaultDNSServers = []string{"8.8.8.8", "8.8.4.4", "1.1.1.1", "1.0.0.1"}
+
+// validateDNSServers rejects entries that are not plain IP addresses
+func validateDNSServers(servers []string) error {
+	for _, server := range servers {
+		if _, err := netip.ParseAddr(server); err != nil {
+			return fmt.Errorf("invalid DNS server %q: expected an IP address", server)
+		}
+	}
+	return nil
+}
+
+// nameserverLines renders servers as resolv.conf nameserver lines
+func nameserverLines(servers []string) string {
+	var b strings.Builder
+	for _, server := range servers {
+		b.WriteString("nameserver " + server + "\n")
+	}
+	return b.String()
+}
+
+// dnsScript returns the shell that writes DNSServers to /etc/resolv.conf, or
+// leaves the file alone when DNSServers is empty
+func (l *LXCBuilder) dnsScript() string {
+	if len(l.DNSServers) == 0 {
+		return `echo "Leaving /etc/resolv.conf untouched"` + "\n"
+	}
+	return "cat > /etc/resolv.conf << 'EOF'\n" + nameserverLines(l.DNSServers) + "EOF\n"
+}
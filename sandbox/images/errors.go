@@ -0,0 +1,98 @@
+package images
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ErrTemplateIncomplete is returned when lxc-create succeeds but the downloaded rootfs is missing or partial
+var ErrTemplateIncomplete = errors.New("template download incomplete")
+
+// ProvisionError describes a failed build step with enough context to act on
+type ProvisionError struct {
+	Err      error
+	Step     string
+	Command  string
+	Hint     string
+	ExitCode int
+}
+
+func (e *ProvisionError) Error() string {
+	var b strings.Builder
+	if e.Step != "" {
+		b.WriteString("failed to " + e.Step)
+	} else {
+		b.WriteString("build command failed")
+	}
+	if e.Command != "" {
+		fmt.Fprintf(&b, ": `%s`", e.Command)
+	}
+	if e.ExitCode > 0 {
+		fmt.Fprintf(&b, " exited with code %d", e.ExitCode)
+	} else if e.Err != nil {
+		fmt.Fprintf(&b, ": %v", e.Err)
+	}
+	if e.Hint != "" {
+		fmt.Fprintf(&b, " (hint: %s)", e.Hint)
+	}
+	return b.String()
+}
+
+func (e *ProvisionError) Unwrap() error {
+	return e.Err
+}
+
+// remediationHints maps output fragments of common failures to a suggested fix, checked in order
+var remediationHints = []struct {
+	pattern string
+	hint    string
+}{
+	{"/dev/kvm", "KVM unavailable: enable nested virtualization or run on a host with /dev/kvm"},
+	{"No space left on device", "free up disk space in the build directory"},
+	{"Temporary failure in name resolution", "DNS resolution failed: check the container DNS servers"},
+	{"Could not resolve", "DNS resolution failed: check the container DNS servers"},
+	{"Failed to download", "image download failed: check network access to the image server"},
+	{"Connection timed out", "network timeout: check connectivity or proxy settings"},
+	{"Connection refused", "network connection refused: check connectivity or proxy settings"},
+	{"Permission denied", "insufficient privileges: run the builder as root"},
+	{"must be run as root", "insufficient privileges: run the builder as root"},
+}
+
+// newProvisionError wraps a failed command, extracting its exit code and a remediation hint
+func newProvisionError(command string, output []byte, err error) *ProvisionError {
+	provisionErr := &ProvisionError{
+		Err:     err,
+		Command: command,
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		provisionErr.ExitCode = exitErr.ExitCode()
+	}
+
+	if errors.Is(err, exec.ErrNotFound) {
+		provisionErr.Hint = fmt.Sprintf("install %s on the build host", strings.Fields(command)[0])
+		return provisionErr
+	}
+
+	for _, h := range remediationHints {
+		if strings.Contains(string(output), h.pattern) {
+			provisionErr.Hint = h.hint
+			break
+		}
+	}
+
+	return provisionErr
+}
+
+// withStep records the failing build step, wrapping plain errors in a ProvisionError
+func withStep(step string, err error) error {
+	var provisionErr *ProvisionError
+	if errors.As(err, &provisionErr) {
+		provisionErr.Step = step
+		return provisionErr
+	}
+	return &ProvisionError{Step: step, Err: err}
+}
@@ -0,0 +1,81 @@
+package images
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestNewProvisionErrorHints(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{output: "Could not access KVM kernel module: /dev/kvm: No such file", want: "KVM unavailable: enable nested virtualization or run on a host with /dev/kvm"},
+		{output: "tar: write error: No space left on device", want: "free up disk space in the build directory"},
+		{output: "Temporary failure in name resolution", want: "DNS resolution failed: check the container DNS servers"},
+		{output: "Could not resolve 'archive.ubuntu.com'", want: "DNS resolution failed: check the container DNS servers"},
+		{output: "ERROR: Failed to download http://images.linuxcontainers.org/meta/1.0/index-system", want: "image download failed: check network access to the image server"},
+		{output: "curl: (28) Connection timed out after 30000 milliseconds", want: "network timeout: check connectivity or proxy settings"},
+		{output: "connect to 10.0.3.1 port 3128: Connection refused", want: "network connection refused: check connectivity or proxy settings"},
+		{output: "lxc-create: Permission denied", want: "insufficient privileges: run the builder as root"},
+		{output: "This command must be run as root", want: "insufficient privileges: run the builder as root"},
+		{output: "E: Unable to locate package openjdk-8-jdk", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			err := newProvisionError("lxc-create -n vm", []byte("some earlier line\n"+tt.output+"\n"), errors.New("exit status 1"))
+			if err.Hint != tt.want {
+				t.Errorf("Hint = %q, want %q", err.Hint, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProvisionErrorUsesFirstMatchingHint(t *testing.T) {
+	// The disk is full because the download failed midway; the earlier entry wins
+	err := newProvisionError("tar -czf x", []byte("Failed to download: No space left on device"), errors.New("exit status 2"))
+	if err.Hint != "free up disk space in the build directory" {
+		t.Errorf("Hint = %q, want the disk space hint", err.Hint)
+	}
+}
+
+func TestNewProvisionErrorExitCode(t *testing.T) {
+	runErr := exec.Command("sh", "-c", "exit 7").Run()
+	err := newProvisionError("sh -c exit 7", nil, runErr)
+
+	if err.ExitCode != 7 {
+		t.Errorf("ExitCode = %d, want 7", err.ExitCode)
+	}
+	if want := "build command failed: `sh -c exit 7` exited with code 7"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestNewProvisionErrorMissingCommand(t *testing.T) {
+	runErr := exec.Command("ra-server-missing-tool", "--version").Run()
+	err := newProvisionError("ra-server-missing-tool --version", []byte("Permission denied"), runErr)
+
+	if err.Hint != "install ra-server-missing-tool on the build host" {
+		t.Errorf("Hint = %q, want an install hint", err.Hint)
+	}
+}
+
+func TestWithStep(t *testing.T) {
+	provisionErr := newProvisionError("lxc-start -n vm", []byte("No space left on device"), errors.New("exit status 1"))
+	err := withStep("start container", provisionErr)
+
+	if err != error(provisionErr) {
+		t.Errorf("withStep() = %v, want the same ProvisionError", err)
+	}
+	if want := "failed to start container: `lxc-start -n vm`: exit status 1 (hint: free up disk space in the build directory)"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	plain := errors.New("boom")
+	wrapped := withStep("copy setup script", plain)
+	if !errors.Is(wrapped, plain) || !strings.HasPrefix(wrapped.Error(), "failed to copy setup script: boom") {
+		t.Errorf("withStep(plain) = %v, want it wrapped with the step", wrapped)
+	}
+}
@@ -0,0 +1,143 @@
+package images
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cynxees/ra-server/internal/helper"
+)
+
+// LayerStrategy selects how a layer container is created from its parent
+type LayerStrategy int
+
+const (
+	// CopyStrategy copies the whole parent container
+	CopyStrategy LayerStrategy = iota
+	// OverlayStrategy mounts the parent rootfs read-only under an overlayfs and stores only changed files
+	OverlayStrategy
+)
+
+func (s LayerStrategy) String() string {
+	switch s {
+	case CopyStrategy:
+		return "copy"
+	case OverlayStrategy:
+		return "overlay"
+	default:
+		return fmt.Sprintf("LayerStrategy(%d)", int(s))
+	}
+}
+
+// overlayUpperDir, overlayWorkDir and overlayOptionsFile live next to rootfs in
+// an overlay layer's container directory
+const (
+	overlayUpperDir    = "delta"
+	overlayWorkDir     = "overlay-work"
+	overlayOptionsFile = "overlay-options"
+)
+
+// overlayAvailable is swapped out in tests to simulate hosts without overlayfs
+var overlayAvailable = func() bool {
+	filesystems, err := os.ReadFile("/proc/filesystems")
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(filesystems), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[len(fields)-1] == "overlay" {
+			return true
+		}
+	}
+	return false
+}
+
+// createOverlayLayer creates containerName with its rootfs mounted as an overlay
+// of the parent rootfs, so writes land in the layer's delta directory. The
+// mount stays after export so the container and layers built on it keep a full
+// rootfs; it is released when the container is destroyed or rebuilt, and
+// mountOverlay restores it after a reboot.
+func (l *LXCBuilder) createOverlayLayer(parentPath, newPath, containerName string) error {
+	l.log("🪜 Mounting overlay on parent layer: %s", filepath.Base(parentPath))
+
+	rootfsPath := filepath.Join(newPath, "rootfs")
+	upperPath := filepath.Join(newPath, overlayUpperDir)
+	workPath := filepath.Join(newPath, overlayWorkDir)
+	for _, dir := range []string{rootfsPath, upperPath, workPath} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return withStep("create overlay directories", err)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(parentPath, "config"))
+	if err != nil {
+		return withStep("read parent config", err)
+	}
+	if err := os.WriteFile(filepath.Join(newPath, "config"), []byte(renameInConfig(string(content), parentPath, newPath, containerName)), 0644); err != nil {
+		return withStep("write layer config", err)
+	}
+
+	// The parent must be mounted too when it is itself an overlay layer
+	if err := l.mountOverlay(parentPath); err != nil {
+		return err
+	}
+
+	options := fmt.Sprintf("lowerdir=%s,upperdir=%s,workdir=%s", filepath.Join(parentPath, "rootfs"), upperPath, workPath)
+	if err := os.WriteFile(filepath.Join(newPath, overlayOptionsFile), []byte(options), 0644); err != nil {
+		return withStep("write overlay options", err)
+	}
+	if err := l.runCommand("mount", "-t", "overlay", "overlay", "-o", options, rootfsPath); err != nil {
+		return withStep("mount overlay rootfs", err)
+	}
+	return nil
+}
+
+// mountOverlay remounts an overlay layer's rootfs with the options it was
+// created with, e.g. after a reboot. An overlay rootfs is never empty once
+// mounted, so a non-empty one is left alone; other containers are a no-op.
+func (l *LXCBuilder) mountOverlay(containerPath string) error {
+	if !l.isOverlayLayer(containerPath) {
+		return nil
+	}
+	rootfsPath := filepath.Join(containerPath, "rootfs")
+	if entries, err := os.ReadDir(rootfsPath); err == nil && len(entries) > 0 {
+		return nil
+	}
+
+	options, err := os.ReadFile(filepath.Join(containerPath, overlayOptionsFile))
+	if err != nil {
+		return withStep("read overlay options", err)
+	}
+	// Layers below this one have to be mounted first
+	for _, option := range strings.Split(string(options), ",") {
+		if lower, ok := strings.CutPrefix(option, "lowerdir="); ok {
+			if err := l.mountOverlay(filepath.Dir(lower)); err != nil {
+				return err
+			}
+		}
+	}
+
+	l.log("🪜 Remounting overlay rootfs: %s", filepath.Base(containerPath))
+	if err := l.runCommand("mount", "-t", "overlay", "overlay", "-o", string(options), rootfsPath); err != nil {
+		return withStep("mount overlay rootfs", err)
+	}
+	return nil
+}
+
+// isOverlayLayer reports whether the container was created by createOverlayLayer
+func (l *LXCBuilder) isOverlayLayer(containerPath string) bool {
+	return l.dirExists(filepath.Join(containerPath, overlayUpperDir))
+}
+
+// unmountOverlay releases an overlay layer's rootfs mount; it is a no-op for other containers
+func (l *LXCBuilder) unmountOverlay(containerPath string) {
+	if !l.isOverlayLayer(containerPath) {
+		return
+	}
+	rootfsPath, err := helper.SafeJoin(containerPath, "rootfs")
+	if err != nil {
+		return
+	}
+	l.runCommand("umount", rootfsPath)
+}
@@ -0,0 +1,48 @@
+package images
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// makeContainerDir creates containerPath with the given subdirectories
+func makeContainerDir(t *testing.T, containerPath string, dirs ...string) {
+	t.Helper()
+	for _, dir := range dirs {
+		if err := os.MkdirAll(filepath.Join(containerPath, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMountOverlaySkipsOtherContainers(t *testing.T) {
+	builder := newTestBuilder(t)
+	containerPath := filepath.Join(builder.ContainerDir, "ubuntu-base")
+	makeContainerDir(t, containerPath, "rootfs")
+
+	if err := builder.mountOverlay(containerPath); err != nil {
+		t.Errorf("mountOverlay() on a copied container error = %v", err)
+	}
+}
+
+func TestMountOverlaySkipsMountedRootfs(t *testing.T) {
+	builder := newTestBuilder(t)
+	containerPath := filepath.Join(builder.ContainerDir, "java8")
+	makeContainerDir(t, containerPath, "rootfs/etc", overlayUpperDir, overlayWorkDir)
+
+	// No options file, so any mount attempt would fail
+	if err := builder.mountOverlay(containerPath); err != nil {
+		t.Errorf("mountOverlay() on a populated rootfs error = %v", err)
+	}
+}
+
+func TestMountOverlayNeedsOptions(t *testing.T) {
+	builder := newTestBuilder(t)
+	containerPath := filepath.Join(builder.ContainerDir, "java8")
+	makeContainerDir(t, containerPath, "rootfs", overlayUpperDir, overlayWorkDir)
+
+	if err := builder.mountOverlay(containerPath); err == nil {
+		t.Error("mountOverlay() remounted an empty rootfs without its options")
+	}
+}
@@ -0,0 +1,99 @@
+package images
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxBuildLogs     = 20
+	defaultMaxBuildLogBytes = 100 << 20
+)
+
+var (
+	logRetentionMu   sync.Mutex
+	maxBuildLogs     = defaultMaxBuildLogs
+	maxBuildLogBytes = int64(defaultMaxBuildLogBytes)
+	buildLogFiles    = true
+)
+
+// SetBuildLogFiles turns the per-build log files in the work directory on or
+// off; build messages reach the app logger either way
+func SetBuildLogFiles(enabled bool) {
+	logRetentionMu.Lock()
+	defer logRetentionMu.Unlock()
+	buildLogFiles = enabled
+}
+
+func buildLogFilesEnabled() bool {
+	logRetentionMu.Lock()
+	defer logRetentionMu.Unlock()
+	return buildLogFiles
+}
+
+// SetLogRetention sets how many build logs are kept per work directory and how
+// large each may grow; values below 1 keep the current setting
+func SetLogRetention(maxLogs int, maxBytes int64) {
+	logRetentionMu.Lock()
+	defer logRetentionMu.Unlock()
+	if maxLogs >= 1 {
+		maxBuildLogs = maxLogs
+	}
+	if maxBytes >= 1 {
+		maxBuildLogBytes = maxBytes
+	}
+}
+
+func logRetention() (int, int64) {
+	logRetentionMu.Lock()
+	defer logRetentionMu.Unlock()
+	return maxBuildLogs, maxBuildLogBytes
+}
+
+// pruneBuildLogs removes all but the keep most recently modified build logs in workDir
+func pruneBuildLogs(workDir string, keep int) error {
+	paths, err := filepath.Glob(filepath.Join(workDir, "build-*.log"))
+	if err != nil || len(paths) <= keep {
+		return err
+	}
+
+	modTimes := make(map[string]int64, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		modTimes[path] = info.ModTime().UnixNano()
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if modTimes[paths[i]] != modTimes[paths[j]] {
+			return modTimes[paths[i]] > modTimes[paths[j]]
+		}
+		return paths[i] > paths[j]
+	})
+
+	for _, path := range paths[keep:] {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old build log %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// createBuildLog creates build-<unix>.log in workDir, adding a -2, -3... suffix
+// when builds started in the same second already hold that name
+func createBuildLog(workDir string, now time.Time) (*os.File, error) {
+	base := fmt.Sprintf("build-%d", now.Unix())
+	name := base
+	for suffix := 2; ; suffix++ {
+		file, err := os.OpenFile(filepath.Join(workDir, name+".log"), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if !os.IsExist(err) {
+			return file, err
+		}
+		name = fmt.Sprintf("%s-%d", base, suffix)
+	}
+}
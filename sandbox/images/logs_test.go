@@ -0,0 +1,97 @@
+package images
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/logger"
+)
+
+// writeBuildLogs creates the named files in dir, each modified a minute after
+// the one before it
+func writeBuildLogs(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	modTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		modTime = modTime.Add(time.Minute)
+	}
+}
+
+func dirEntries(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestPruneBuildLogs(t *testing.T) {
+	// Named against their age so the order can only come from mtimes
+	logs := []string{"build-5.log", "build-4.log", "build-3.log", "build-2.log", "build-1.log"}
+	tests := []struct {
+		name string
+		want []string
+		keep int
+	}{
+		{name: "keeps newest", keep: 2, want: []string{"build-1.log", "build-2.log", "notes.txt"}},
+		{name: "keep none", keep: 0, want: []string{"notes.txt"}},
+		{name: "keep all", keep: len(logs), want: append([]string{"notes.txt"}, logs...)},
+		{name: "keep more than exist", keep: 10, want: append([]string{"notes.txt"}, logs...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeBuildLogs(t, dir, logs...)
+			writeBuildLogs(t, dir, "notes.txt")
+
+			if err := pruneBuildLogs(dir, tt.keep); err != nil {
+				t.Fatalf("pruneBuildLogs() error = %v", err)
+			}
+			got := dirEntries(t, dir)
+			want := slices.Clone(tt.want)
+			slices.Sort(want)
+			if !slices.Equal(got, want) {
+				t.Errorf("remaining files = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestNewLXCBuilderPrunesToRetention(t *testing.T) {
+	previous := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	t.Cleanup(func() { logger.SetWriter(previous) })
+	maxLogs, maxBytes := logRetention()
+	SetLogRetention(3, 0)
+	t.Cleanup(func() { SetLogRetention(maxLogs, maxBytes) })
+
+	workDir := t.TempDir()
+	writeBuildLogs(t, workDir, "build-1.log", "build-2.log", "build-3.log", "build-4.log")
+
+	l := NewLXCBuilder(context.Background(), workDir, t.TempDir())
+	if l.LogFile == nil {
+		t.Fatal("NewLXCBuilder did not create a build log")
+	}
+	defer l.LogFile.Close()
+
+	want := []string{"build-3.log", "build-4.log", filepath.Base(l.LogFile.Name())}
+	slices.Sort(want)
+	if got := dirEntries(t, workDir); !slices.Equal(got, want) {
+		t.Errorf("build logs = %v, want %v", got, want)
+	}
+}
@@ -1,12 +1,20 @@
 package images
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/netip"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/cynxees/ra-server/internal/helper"
+	"github.com/cynxees/ra-server/internal/logger"
 )
 
 // LXCBuilder handles LXC container creation from Dockerfile-like instructions
@@ -14,108 +22,549 @@ type LXCBuilder struct {
 	WorkDir      string
 	ContainerDir string
 	LogFile      *os.File
+	// ExcludePaths are rootfs-relative directories whose contents are left out of exported archives
+	ExcludePaths []string
+	// RequiredDiskBytes is the space a build is expected to use; DiskHeadroomBytes is kept free on top of it
+	RequiredDiskBytes uint64
+	DiskHeadroomBytes uint64
+	// Clock supplies timestamps for log lines, archive names and metadata, and times readiness waits
+	Clock Clock
+	// Spec selects the distribution, release and architecture of new containers
+	Spec ContainerSpec
+	// Bridge is the host bridge the container's veth interface is attached to
+	Bridge string
+	// StaticIPv4 is an optional CIDR address (e.g. 10.0.3.10/24) used instead of DHCP, with IPv4Gateway as its default route
+	StaticIPv4  string
+	IPv4Gateway string
+	// HTTPProxy, HTTPSProxy and NoProxy are passed to in-container commands; they default to the host's settings
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+	// DNSServers are written to the container's resolv.conf during setup; empty leaves it untouched
+	DNSServers []string
+	// Steps run inside the container after its built-in setup, each checked by sanitizeStep
+	Steps []RunStep
+	// AptMirror replaces the Ubuntu archive in the container's sources.list when set
+	AptMirror string
+	// MaxCapturedOutput caps how many trailing bytes of command output are held in memory
+	MaxCapturedOutput int
+	// ReadyTimeout bounds how long a started container may take to report RUNNING
+	ReadyTimeout time.Duration
+	// LayerStrategy selects how layers are created from their parent; overlay falls back to copy without overlayfs
+	LayerStrategy LayerStrategy
+	// KeepOnFailure leaves a failed build's container in place for debugging instead of destroying it
+	KeepOnFailure bool
+
+	// ctx cancels running commands once the build is aborted or times out, and
+	// carries the request fields build messages are logged with
+	ctx context.Context
+	// MaxLogBytes caps LogFile; output past it is dropped from the file
+	MaxLogBytes int64
+
+	// logMu serializes writes to LogFile so lines never interleave
+	logMu sync.Mutex
+	// logBytes counts what has been written to LogFile
+	logBytes int64
 }
 
-// NewLXCBuilder creates a new LXC builder instance
-func NewLXCBuilder(workDir, containerDir string) *LXCBuilder {
-	logFile, err := os.Create(filepath.Join(workDir, fmt.Sprintf("build-%d.log", time.Now().Unix())))
+const (
+	defaultRequiredDiskBytes = 3 << 30
+	defaultDiskHeadroomBytes = 1 << 30
+	defaultReadyTimeout      = 30 * time.Second
+	defaultBridge            = "lxcbr0"
+	readyPollInterval        = time.Second
+	createAttempts           = 3
+	createRetryBackoff       = 5 * time.Second
+)
+
+// defaultExcludePaths keeps caches, logs and scratch files out of exported templates
+var defaultExcludePaths = []string{"var/cache/apt", "var/log", "tmp"}
+
+// NewLXCBuilder creates a new LXC builder instance whose messages are logged with
+// ctx and, unless build log files are disabled, to a new build log in workDir
+func NewLXCBuilder(ctx context.Context, workDir, containerDir string) *LXCBuilder {
+	l := newContainerBuilder(ctx, workDir, containerDir)
+	if !buildLogFilesEnabled() {
+		return l
+	}
+
+	// Make room for the log created below
+	maxLogs, _ := logRetention()
+	if err := pruneBuildLogs(workDir, maxLogs-1); err != nil {
+		logger.FromContext(ctx).Warn("Failed to prune build logs: ", err)
+	}
+	logFile, err := createBuildLog(workDir, l.Clock.Now())
 	if err != nil {
-		fmt.Printf("Warning: Failed to create log file: %v\n", err)
+		logger.FromContext(ctx).Warn("Failed to create log file: ", err)
+		return l
 	}
+	l.LogFile = logFile
+	return l
+}
+
+// newContainerBuilder returns a builder that only logs to the app logger, for
+// managing existing containers without creating or pruning build logs
+func newContainerBuilder(ctx context.Context, workDir, containerDir string) *LXCBuilder {
+	clock := realClock{}
+	_, maxLogBytes := logRetention()
 
 	return &LXCBuilder{
 		WorkDir:      workDir,
 		ContainerDir: containerDir,
-		LogFile:      logFile,
+		ExcludePaths: append([]string(nil), defaultExcludePaths...),
+
+		RequiredDiskBytes: defaultRequiredDiskBytes,
+		DiskHeadroomBytes: defaultDiskHeadroomBytes,
+		Clock:             clock,
+		ReadyTimeout:      defaultReadyTimeout,
+		MaxCapturedOutput: defaultMaxCapturedOutput,
+		HTTPProxy:         proxyFromEnv("http_proxy"),
+		HTTPSProxy:        proxyFromEnv("https_proxy"),
+		NoProxy:           proxyFromEnv("no_proxy"),
+		MaxLogBytes:       maxLogBytes,
+		Bridge:            defaultBridge,
+		Spec:              defaultContainerSpec,
+		DNSServers:        append([]string(nil), defaultDNSServers...),
+		ctx:               ctx,
+	}
+}
+
+// freeDiskSpace is swapped out in tests to simulate a full disk
+var freeDiskSpace = helper.FreeDiskSpace
+
+// checkDiskSpace fails early when the work or container directory lacks room for a build
+func (l *LXCBuilder) checkDiskSpace() error {
+	needed := l.RequiredDiskBytes + l.DiskHeadroomBytes
+	for _, dir := range []string{l.WorkDir, l.ContainerDir} {
+		free, err := freeDiskSpace(dir)
+		if err != nil {
+			return withStep("check disk space", err)
+		}
+		if free < needed {
+			return &ProvisionError{
+				Step: "check disk space",
+				Err:  fmt.Errorf("%s has %d MB free, build needs %d MB", dir, free>>20, needed>>20),
+				Hint: "free up disk space in the build directory",
+			}
+		}
 	}
+	return nil
+}
+
+// containerState is swapped out in tests to simulate lxc-info output
+var containerState = func(l *LXCBuilder, containerName string) (string, error) {
+	output, err := l.commandOutput("lxc-info", "-n", containerName, "-P", l.ContainerDir, "-s")
+	if err != nil {
+		return "", err
+	}
+	return parseLXCInfo(output).State, nil
+}
+
+// waitForRunning polls the container state until it is RUNNING or ReadyTimeout
+// elapses. Time is counted in waits on l.Clock rather than read from it, so a
+// FixedClock still times out.
+func (l *LXCBuilder) waitForRunning(containerName string) error {
+	var waited time.Duration
+	state := "UNKNOWN"
+	for {
+		current, err := containerState(l, containerName)
+		if err == nil {
+			state = current
+			if state == "RUNNING" {
+				return nil
+			}
+		}
+		if waited >= l.ReadyTimeout {
+			return &ProvisionError{
+				Step: "wait for container",
+				Err:  fmt.Errorf("%s still %s after %s", containerName, state, l.ReadyTimeout),
+				Hint: "check the container log with lxc-start -F or lxc-info",
+			}
+		}
+		wait := min(readyPollInterval, l.ReadyTimeout-waited)
+		select {
+		case <-l.ctx.Done():
+			return withStep("wait for container", l.ctx.Err())
+		case <-l.Clock.After(wait):
+		}
+		waited += wait
+	}
+}
+
+// excludeArgs builds tar --exclude flags for ExcludePaths, keeping the directories themselves
+func (l *LXCBuilder) excludeArgs() []string {
+	args := make([]string, 0, len(l.ExcludePaths))
+	for _, path := range l.ExcludePaths {
+		path = strings.Trim(filepath.Clean(path), "/")
+		if path == "" || path == "." {
+			continue
+		}
+		args = append(args, "--exclude=./"+path+"/*")
+	}
+	return args
 }
 
 // Close cleans up resources
 func (l *LXCBuilder) Close() {
+	l.logMu.Lock()
+	defer l.logMu.Unlock()
+
 	if l.LogFile != nil {
 		l.LogFile.Close()
+		l.LogFile = nil
 	}
 }
 
-// log writes a message to both stdout and log file
+// log sends a message to the app logger and the build log file
 func (l *LXCBuilder) log(format string, args ...interface{}) {
-	timestamp := time.Now().Format("15:04:05")
-	message := fmt.Sprintf("[%s] %s", timestamp, fmt.Sprintf(format, args...))
+	message := fmt.Sprintf(format, args...)
+	logger.FromContext(l.ctx).Info(message)
+	l.writeLog(fmt.Sprintf("[%s] %s\n", l.Clock.Now().Format("15:04:05"), message))
+}
+
+// warn is log for problems the build recovers from
+func (l *LXCBuilder) warn(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	logger.FromContext(l.ctx).Warn(message)
+	l.writeLog(fmt.Sprintf("[%s] Warning: %s\n", l.Clock.Now().Format("15:04:05"), message))
+}
 
-	fmt.Println(message)
+// writeLog writes text to the build log file as a single unit
+func (l *LXCBuilder) writeLog(text string) {
+	l.logMu.Lock()
+	defer l.logMu.Unlock()
 
-	if l.LogFile != nil {
-		l.LogFile.WriteString(message + "\n")
-		l.LogFile.Sync()
+	if l.LogFile == nil {
+		return
+	}
+	if l.MaxLogBytes > 0 && l.logBytes+int64(len(text)) > l.MaxLogBytes {
+		l.LogFile.WriteString(fmt.Sprintf("[log truncated at %d bytes]\n", l.logBytes))
+		l.LogFile.Close()
+		l.LogFile = nil
+		return
 	}
+	n, _ := l.LogFile.WriteString(text)
+	l.logBytes += int64(n)
+	l.LogFile.Sync()
 }
 
-// runCommand executes a command and captures output
-func (l *LXCBuilder) runCommand(name string, args ...string) error {
-	l.log("Running: %s %s", name, strings.Join(args, " "))
+// execCommand is swapped out in tests to fake the tools a build runs
+var execCommand = exec.CommandContext
+
+// runCommandWithRetry runs a command up to attempts times, doubling the wait
+// after each non-zero exit. Cancellation of the build is never retried.
+func (l *LXCBuilder) runCommandWithRetry(attempts int, backoff time.Duration, name string, args ...string) error {
+	attempt := 0
+	return helper.Retry(l.ctx, attempts, backoff, func() error {
+		attempt++
+		if attempt > 1 {
+			l.log("🔁 Retrying %s (attempt %d/%d)", name, attempt, attempts)
+		}
 
-	cmd := exec.Command(name, args...)
-	output, err := cmd.CombinedOutput()
+		err := l.runCommand(name, args...)
+		if err == nil || l.ctx.Err() != nil {
+			return err
+		}
+		l.warn("%s failed on attempt %d/%d: %v", name, attempt, attempts, err)
+		return helper.Retryable(err)
+	})
+}
 
-	if len(output) > 0 {
-		fmt.Print(string(output))
-		if l.LogFile != nil {
-			l.LogFile.WriteString(string(output))
-			l.LogFile.Sync()
+// runCommand executes a command, logging its output and returning a ProvisionError on failure
+func (l *LXCBuilder) runCommand(name string, args ...string) error {
+	command := strings.TrimSpace(name + " " + strings.Join(args, " "))
+	l.log("Running: %s", command)
+
+	// Output is streamed to the log as it arrives; only its tail is kept for error hints
+	output := newTailBuffer(l.MaxCapturedOutput)
+	writer := &commandLogWriter{l: l, tail: output}
+
+	cmd := execCommand(l.ctx, name, args...)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	killOnCancel(cmd)
+	if err := cmd.Run(); err != nil {
+		if ctxErr := l.ctx.Err(); ctxErr != nil {
+			l.log("🛑 Cancelled: %s", command)
+			return ctxErr
 		}
+		return newProvisionError(command, output.Bytes(), err)
 	}
+	return nil
+}
 
-	return err
+// BuildOptions overrides builder settings for a single build; zero values keep the builder defaults
+type BuildOptions struct {
+	// OnStart is called with the build log path once the build begins; it is
+	// not part of what the build produces, so it is left out of encodings
+	OnStart func(logPath string) `json:"-"`
+	// Spec replaces the default Ubuntu jammy amd64 image when set
+	Spec        ContainerSpec
+	Bridge      string
+	StaticIPv4  string
+	IPv4Gateway string
+	AptMirror   string
+	// DNSServers replaces the default resolvers when non-nil; an empty slice leaves resolv.conf untouched
+	DNSServers []string
+	// Steps are extra provisioning commands run inside the container
+	Steps []RunStep
+	// ReadyTimeout bounds how long the container may take to report RUNNING
+	ReadyTimeout time.Duration
+	// LayerStrategy replaces the default copy strategy when set
+	LayerStrategy LayerStrategy
+	// KeepOnFailure leaves a failed build's container in place for debugging
+	KeepOnFailure bool
 }
 
-// RunUbuntuContainer creates an Ubuntu 22.04 LXC container like a Dockerfile
-func RunUbuntuContainer() {
+// apply copies the set options onto the builder
+func (o BuildOptions) apply(l *LXCBuilder) {
+	if o.Spec != (ContainerSpec{}) {
+		l.Spec = o.Spec
+	}
+	if o.Bridge != "" {
+		l.Bridge = o.Bridge
+	}
+	if o.StaticIPv4 != "" {
+		l.StaticIPv4 = o.StaticIPv4
+		l.IPv4Gateway = o.IPv4Gateway
+	}
+	if o.AptMirror != "" {
+		l.AptMirror = o.AptMirror
+	}
+	if o.ReadyTimeout > 0 {
+		l.ReadyTimeout = o.ReadyTimeout
+	}
+	if o.DNSServers != nil {
+		l.DNSServers = o.DNSServers
+	}
+	if len(o.Steps) > 0 {
+		l.Steps = o.Steps
+	}
+	if o.LayerStrategy != CopyStrategy {
+		l.LayerStrategy = o.LayerStrategy
+	}
+	if o.KeepOnFailure {
+		l.KeepOnFailure = true
+	}
+}
+
+// BuildResult describes the artifacts produced by a container build
+type BuildResult struct {
+	ContainerName string
+	ArchivePath   string
+	LogPath       string
+	// Checksum is the archive's hex SHA256, also written to ArchivePath + ".sha256"
+	Checksum string
+	// IPAddress is the container's static address, empty when it uses DHCP
+	IPAddress string
+}
+
+// containerBuild describes how a known container image is built
+type containerBuild struct {
+	build  func(l *LXCBuilder, containerName, parentLayer string) error
+	parent string
+	// layerPaths are the rootfs-relative paths a layer adds on top of its parent
+	layerPaths []string
+}
+
+// containerBuilds lists the container images that can be built by name
+var containerBuilds = map[string]containerBuild{
+	"ubuntu-base": {
+		build: func(l *LXCBuilder, containerName, _ string) error { return buildUbuntuContainer(l, containerName) },
+	},
+	"ubuntu-java8": {
+		build:      buildJava8Layer,
+		parent:     "ubuntu-base",
+		layerPaths: []string{"usr/lib/jvm", "etc/environment", "etc/bash.bashrc", "usr/bin/java", "usr/bin/javac"},
+	},
+}
+
+// HasContainerBuild reports whether name is a container image BuildContainer knows how to build
+func HasContainerBuild(name string) bool {
+	_, ok := containerBuilds[name]
+	return ok
+}
+
+// BuildContainer builds the named container image and exports it as a tar.gz template.
+// The container is named the way GenerateBuildName names it, so BuildResult.ContainerName
+// differs from name. opts.OnStart, when set, receives the build log path once
+// the builder is ready.
+func BuildContainer(ctx context.Context, name string, opts BuildOptions) (*BuildResult, error) {
+	build, ok := containerBuilds[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown container image %q", name)
+	}
+	return runContainerBuild(ctx, name, build, opts)
+}
+
+// buildDirs returns the work and container directories builds use under the current directory
+func buildDirs() (workDir, containerDir string, err error) {
 	pwd, err := os.Getwd()
 	if err != nil {
-		fmt.Printf("Failed to get current directory: %v\n", err)
-		return
+		return "", "", fmt.Errorf("failed to get current directory: %w", err)
 	}
 
-	workDir := filepath.Join(pwd, "sandbox", "build", "lxc-ubuntu")
-	containerDir := filepath.Join(workDir, "containers")
+	workDir = filepath.Join(pwd, "sandbox", "build", "lxc-ubuntu")
+	return workDir, filepath.Join(workDir, "containers"), nil
+}
+
+// runContainerBuild prepares the build directories and builder, runs build and
+// exports the container. Layers start from the newest build of their parent
+// with the same options.
+func runContainerBuild(ctx context.Context, base string, build containerBuild, opts BuildOptions) (*BuildResult, error) {
+	workDir, containerDir, err := buildDirs()
+	if err != nil {
+		return nil, err
+	}
 
 	// Create directories
 	if err := os.MkdirAll(workDir, 0755); err != nil {
-		fmt.Printf("Failed to create work directory: %v\n", err)
-		return
+		return nil, fmt.Errorf("failed to create work directory: %w", err)
 	}
 	if err := os.MkdirAll(containerDir, 0755); err != nil {
-		fmt.Printf("Failed to create container directory: %v\n", err)
-		return
+		return nil, fmt.Errorf("failed to create container directory: %w", err)
 	}
 
-	fmt.Printf("🏗️  Work directory: %s\n", workDir)
-	fmt.Printf("🐳 Container directory: %s\n", containerDir)
+	release, err := acquireBuildSlot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire build slot: %w", err)
+	}
+	defer release()
 
-	builder := NewLXCBuilder(workDir, containerDir)
+	var parentLayer string
+	if build.parent != "" {
+		parentLayer, _ = latestBuildName(containerDir, build.parent, opts.buildSpec("", nil))
+	}
+	spec := opts.buildSpec(parentLayer, build.layerPaths)
+	containerName, err := reserveBuildName(workDir, containerDir, base, spec)
+	if err != nil {
+		return nil, err
+	}
+	containerPath := filepath.Join(containerDir, containerName)
+	// Release the reservation if the build never populated the directory;
+	// os.Remove leaves a created container alone
+	defer os.Remove(containerPath)
+
+	builder := NewLXCBuilder(ctx, workDir, containerDir)
 	defer builder.Close()
+	opts.apply(builder)
 
-	if err := buildUbuntuContainer(builder); err != nil {
-		fmt.Printf("Container build failed: %v\n", err)
-		return
+	timeout := currentBuildTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	builder.ctx = ctx
+
+	result := &BuildResult{ContainerName: containerName}
+	if prefix, err := netip.ParsePrefix(builder.StaticIPv4); err == nil {
+		result.IPAddress = prefix.Addr().String()
+	}
+	if builder.LogFile != nil {
+		result.LogPath = builder.LogFile.Name()
+	}
+	if opts.OnStart != nil {
+		opts.OnStart(result.LogPath)
+	}
+
+	builder.log("🏗️  Work directory: %s", workDir)
+	builder.log("🐳 Container directory: %s", containerDir)
+
+	if err := builder.Spec.Validate(); err != nil {
+		return result, fmt.Errorf("preflight failed: %w", err)
+	}
+	if err := validateDNSServers(builder.DNSServers); err != nil {
+		return result, fmt.Errorf("preflight failed: %w", err)
+	}
+	if err := builder.checkDiskSpace(); err != nil {
+		return result, fmt.Errorf("preflight failed: %w", err)
+	}
+	for _, step := range builder.Steps {
+		if err := builder.sanitizeStep(step); err != nil {
+			return result, fmt.Errorf("preflight failed: %w", err)
+		}
+	}
+
+	if err := build.build(builder, containerName, parentLayer); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			builder.abortBuild(containerName)
+			return result, fmt.Errorf("%w after %s: %w", ErrBuildTimeout, timeout, err)
+		}
+		return result, fmt.Errorf("container build failed: %w", err)
 	}
 
-	fmt.Println("✅ Ubuntu 22.04 LXC container created successfully!")
-	containerPath := filepath.Join(containerDir, "ubuntu-base")
-	fmt.Printf("📁 Container location: %s\n", containerPath)
+	builder.log("✅ %s container created successfully!", containerName)
+	builder.log("📁 Container location: %s", containerPath)
 
 	// Export container as tar.gz
-	if err := exportContainerAsTarGz(builder, workDir, containerPath); err != nil {
-		fmt.Printf("Container export failed: %v\n", err)
+	archivePath, checksum, err := exportContainerAsTarGz(builder, workDir, containerPath, parentLayer, build.layerPaths)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			builder.abortBuild(containerName)
+			return result, fmt.Errorf("%w after %s: %w", ErrBuildTimeout, timeout, err)
+		}
+		return result, fmt.Errorf("container export failed: %w", err)
+	}
+	result.ArchivePath = archivePath
+	result.Checksum = checksum
+
+	builder.removeSupersededBuilds(base, spec, containerName)
+	return result, nil
+}
+
+// abortBuild stops a timed-out build's container and releases its mounts
+func (l *LXCBuilder) abortBuild(containerName string) {
+	// The build context has expired, so cleanup gets its own
+	buildCtx := l.ctx
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(buildCtx), cleanupTimeout)
+	defer cancel()
+	l.ctx = ctx
+	defer func() { l.ctx = buildCtx }()
+
+	l.log("⌛ Build timed out, cleaning up %s...", containerName)
+	l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir, "-k")
+	l.cleanupMounts(filepath.Join(l.ContainerDir, containerName, "rootfs"))
+}
+
+// discardFailedBuild stops and destroys a container whose build failed and
+// releases its mounts, unless KeepOnFailure is set. Every step tolerates the
+// container or mounts already being gone, so it is safe to run twice.
+func (l *LXCBuilder) discardFailedBuild(containerName string) {
+	if l.KeepOnFailure {
+		l.log("🔍 Keeping failed container %s for debugging", containerName)
 		return
 	}
+
+	// The build context may be what failed, so cleanup gets its own
+	buildCtx := l.ctx
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(buildCtx), cleanupTimeout)
+	defer cancel()
+	l.ctx = ctx
+	defer func() { l.ctx = buildCtx }()
+
+	containerPath := filepath.Join(l.ContainerDir, containerName)
+	l.log("🧹 Build failed, removing %s...", containerName)
+	l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir, "-k")
+	l.cleanupMounts(filepath.Join(containerPath, "rootfs"))
+	l.unmountOverlay(containerPath)
+	l.runCommand("lxc-destroy", "-n", containerName, "-P", l.ContainerDir)
 }
 
-// buildUbuntuContainer creates Ubuntu 22.04 container with Dockerfile-like steps
-func buildUbuntuContainer(l *LXCBuilder) error {
-	containerName := "ubuntu-base"
+// RunUbuntuContainer creates an Ubuntu 22.04 LXC container like a Dockerfile
+func RunUbuntuContainer(ctx context.Context, opts BuildOptions) (*BuildResult, error) {
+	return BuildContainer(ctx, "ubuntu-base", opts)
+}
+
+// buildUbuntuContainer creates the base container from l.Spec (Ubuntu 22.04 by default) with Dockerfile-like steps
+func buildUbuntuContainer(l *LXCBuilder, containerName string) (err error) {
+	defer func() {
+		if err != nil {
+			l.discardFailedBuild(containerName)
+		}
+	}()
 
-	l.log("🐳 FROM ubuntu:22.04 - Creating Ubuntu 22.04 LXC container...")
+	l.log("🐳 FROM %s:%s - Creating %s LXC container...", l.Spec.Dist, l.Spec.Release, l.Spec)
 
 	// Clean up any existing container
 	l.log("Cleaning up any existing container: %s", containerName)
@@ -123,47 +572,43 @@ func buildUbuntuContainer(l *LXCBuilder) error {
 	l.runCommand("lxc-destroy", "-n", containerName, "-P", l.ContainerDir)
 
 	// Create LXC container - equivalent to FROM ubuntu:22.04
-	if err := l.runCommand("lxc-create", "-t", "download", "-n", containerName, "-P", l.ContainerDir, "--", "--dist", "ubuntu", "--release", "jammy", "--arch", "amd64"); err != nil {
-		return fmt.Errorf("failed to create LXC container: %w", err)
+	createArgs := append([]string{"-t", "download", "-n", containerName, "-P", l.ContainerDir, "--"}, l.Spec.createArgs()...)
+	if err := l.runCommandWithRetry(createAttempts, createRetryBackoff, "lxc-create", createArgs...); err != nil {
+		return withStep("create LXC container", err)
+	}
+	if err := verifyRootfs(filepath.Join(l.ContainerDir, containerName, "rootfs")); err != nil {
+		return &ProvisionError{
+			Step: "verify downloaded template",
+			Err:  err,
+			Hint: "the download was cut short: retry the build, or clear the cache under /var/cache/lxc/download",
+		}
 	}
 
 	// Configure container for better compatibility
-	configPath := filepath.Join(l.ContainerDir, containerName, "config")
-	additionalConfig := `
-# Enable networking with veth and bridge
-lxc.net.0.type = veth
-lxc.net.0.link = lxcbr0
-lxc.net.0.flags = up
-lxc.net.0.hwaddr = 00:16:3e:xx:xx:xx
-lxc.apparmor.profile = unconfined
-lxc.cap.drop = 
-`
-	if err := l.appendToConfig(configPath, additionalConfig); err != nil {
-		l.log("Warning: failed to modify container config: %v", err)
+	if err := l.configureNetwork(filepath.Join(l.ContainerDir, containerName, "config")); err != nil {
+		return err
 	}
 
 	// Get rootfs path for later use
 	rootfsPath := filepath.Join(l.ContainerDir, containerName, "rootfs")
 	l.log("🔧 Container rootfs location: %s", rootfsPath)
 
-	// Create a script to run inside container
-	scriptPath := filepath.Join(l.ContainerDir, "setup.sh")
+	if err := l.configureAptMirror(rootfsPath); err != nil {
+		return err
+	}
+
+	// Create a script to run inside container, kept in the container's own
+	// directory so concurrent builds never share it
+	scriptPath := filepath.Join(l.ContainerDir, containerName, "setup.sh")
 	setupScript := `#!/bin/bash
 set -e
 
 # Setup DNS resolution first
 echo "🌐 Setting up DNS resolution..."
-cat > /etc/resolv.conf << 'EOF'
-# DNS configuration for LXC container
-nameserver 8.8.8.8
-nameserver 8.8.4.4
-nameserver 1.1.1.1
-nameserver 1.0.0.1
-EOF
-
+` + l.dnsScript() + `
 # Test DNS resolution
 echo "🔍 Testing DNS resolution..."
-nslookup archive.ubuntu.com || echo "Warning: DNS resolution test failed"
+nslookup ` + l.Spec.archiveHost() + ` || echo "Warning: DNS resolution test failed"
 
 # Update package lists
 echo "📦 RUN apt-get update"
@@ -205,40 +650,43 @@ echo "✅ Container setup completed successfully"
 	// Start the container to enable network access for package installation
 	l.log("🚀 Starting container for package installation...")
 	if err := l.runCommand("lxc-start", "-n", containerName, "-P", l.ContainerDir, "-d"); err != nil {
-		l.log("Warning: failed to start container, falling back to chroot")
+		l.warn("Failed to start container, falling back to chroot")
 		return l.fallbackToChroot(rootfsPath, scriptPath)
 	}
 
 	// Wait for container to be ready
 	l.log("⏳ Waiting for container to be ready...")
-	for i := 0; i < 30; i++ {
-		if err := l.runCommand("lxc-info", "-n", containerName, "-P", l.ContainerDir, "-s"); err == nil {
-			break
-		}
-		time.Sleep(1 * time.Second)
+	if err := l.waitForRunning(containerName); err != nil {
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		return err
 	}
 
 	// Setup DNS immediately in the running container
-	l.log("🌐 Setting up DNS in running container...")
-	dnsSetupCmd := `echo 'nameserver 8.8.8.8
-nameserver 8.8.4.4
-nameserver 1.1.1.1
-nameserver 1.0.0.1' > /etc/resolv.conf`
-	if err := l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "/bin/bash", "-c", dnsSetupCmd); err != nil {
-		l.log("Warning: failed to setup DNS in container: %v", err)
+	if len(l.DNSServers) > 0 {
+		l.log("🌐 Setting up DNS in running container...")
+		if err := l.runCommand("lxc-attach", l.attachArgs(containerName, "/bin/bash", "-c", l.dnsScript())...); err != nil {
+			l.warn("Failed to setup DNS in container: %v", err)
+		}
 	}
 
 	// Copy script into container
 	containerScriptPath := filepath.Join(rootfsPath, "setup.sh")
 	if err := l.runCommand("cp", scriptPath, containerScriptPath); err != nil {
 		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
-		return fmt.Errorf("failed to copy setup script: %w", err)
+		return withStep("copy setup script", err)
 	}
 
 	// Execute script inside running container
 	l.log("🔧 Running setup script in container...")
-	if err := l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "/bin/bash", "/setup.sh"); err != nil {
-		l.log("Warning: setup script execution failed: %v", err)
+	if err := l.runCommand("lxc-attach", l.attachArgs(containerName, "/bin/bash", "/setup.sh")...); err != nil {
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		l.cleanupMounts(rootfsPath)
+		return withStep("run setup script", err)
+	}
+	if err := l.runSteps(containerName); err != nil {
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		l.cleanupMounts(rootfsPath)
+		return err
 	}
 
 	// Stop the container
@@ -256,16 +704,62 @@ nameserver 1.0.0.1' > /etc/resolv.conf`
 	return nil
 }
 
-// appendToConfig appends text to a container config file
-func (l *LXCBuilder) appendToConfig(configPath, text string) error {
-	file, err := os.OpenFile(configPath, os.O_APPEND|os.O_WRONLY, 0644)
+// configureNetwork appends the bridge, static address and security settings to
+// the container config at configPath
+func (l *LXCBuilder) configureNetwork(configPath string) error {
+	if err := validateBridgeName(l.Bridge); err != nil {
+		return err
+	}
+	if err := ValidateStaticIPv4(l.StaticIPv4, l.IPv4Gateway); err != nil {
+		return err
+	}
+	additionalConfig, err := renderTemplate(lxcConfigTemplate, lxcConfigData{
+		Bridge:          l.Bridge,
+		HWAddr:          "00:16:3e:xx:xx:xx",
+		ApparmorProfile: "unconfined",
+		IPv4Address:     l.StaticIPv4,
+		IPv4Gateway:     l.IPv4Gateway,
+	})
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	if err := l.appendToConfig(configPath, additionalConfig); err != nil {
+		return withStep("configure container", err)
+	}
+	return nil
+}
 
-	_, err = file.WriteString(text)
-	return err
+// appendToConfig appends text to a container config file, starting it on a
+// new line if the file lacks a trailing newline, and syncs before closing
+func (l *LXCBuilder) appendToConfig(configPath, text string) (err error) {
+	file, err := os.OpenFile(configPath, os.O_APPEND|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+			return err
+		}
+		if last[0] != '\n' {
+			text = "\n" + text
+		}
+	}
+
+	if _, err := file.WriteString(text); err != nil {
+		return err
+	}
+	return file.Sync()
 }
 
 // setupContainerDNS configures DNS resolution for the container
@@ -278,21 +772,20 @@ func (l *LXCBuilder) setupContainerDNS(rootfsPath string) error {
 		return fmt.Errorf("failed to create /etc directory: %w", err)
 	}
 
+	if len(l.DNSServers) == 0 {
+		l.log("Leaving container resolv.conf untouched")
+		return nil
+	}
+
 	// Copy host's resolv.conf for DNS resolution, then add fallback DNS servers
 	hostResolvConf, err := os.ReadFile("/etc/resolv.conf")
 	if err != nil {
-		l.log("Warning: failed to read host resolv.conf, using fallback DNS")
+		l.warn("Failed to read host resolv.conf, using fallback DNS")
 		hostResolvConf = []byte("")
 	}
 
 	// Create comprehensive DNS configuration
-	resolvConf := string(hostResolvConf) + `
-# Fallback DNS servers for LXC container
-nameserver 8.8.8.8
-nameserver 8.8.4.4
-nameserver 1.1.1.1
-nameserver 1.0.0.1
-`
+	resolvConf := string(hostResolvConf) + "\n# Fallback DNS servers for LXC container\n" + nameserverLines(l.DNSServers)
 	resolvPath := filepath.Join(etcPath, "resolv.conf")
 	if err := os.WriteFile(resolvPath, []byte(resolvConf), 0644); err != nil {
 		return fmt.Errorf("failed to write resolv.conf: %w", err)
@@ -317,18 +810,19 @@ func (l *LXCBuilder) fallbackToChroot(rootfsPath, scriptPath string) error {
 	// Copy script into container
 	containerScriptPath := filepath.Join(rootfsPath, "setup.sh")
 	if err := l.runCommand("cp", scriptPath, containerScriptPath); err != nil {
-		return fmt.Errorf("failed to copy setup script: %w", err)
+		return withStep("copy setup script", err)
 	}
 
 	// Make script executable
 	if err := os.Chmod(containerScriptPath, 0755); err != nil {
-		l.log("Warning: failed to make setup script executable: %v", err)
+		return withStep("make setup script executable", err)
 	}
 
 	// Execute script in chroot (network will be limited)
-	if err := l.runCommand("chroot", rootfsPath, "/bin/bash", "/setup.sh"); err != nil {
-		l.log("Warning: chroot setup script execution failed: %v", err)
-		// Continue anyway as basic container structure is created
+	chrootArgs := append([]string{rootfsPath, "/usr/bin/env"}, l.proxyEnv()...)
+	chrootArgs = append(chrootArgs, "/bin/bash", "/setup.sh")
+	if err := l.runCommand("chroot", chrootArgs...); err != nil {
+		return withStep("run setup script in chroot", err)
 	}
 
 	return nil
@@ -348,8 +842,12 @@ func (l *LXCBuilder) cleanupMounts(rootfsPath string) {
 	l.runCommand("umount", procPath)
 }
 
-// createProxmoxMetadata ensures proper metadata for Proxmox compatibility
+// createProxmoxMetadata ensures proper metadata for Proxmox compatibility.
+// Only Ubuntu jammy gets the pinned release files; other images keep their own.
 func (l *LXCBuilder) createProxmoxMetadata(rootfsPath string) error {
+	if l.Spec.Dist != defaultContainerSpec.Dist || l.Spec.Release != defaultContainerSpec.Release {
+		return nil
+	}
 	l.log("📝 Creating Proxmox metadata...")
 
 	// Ensure /etc/os-release exists for Proxmox autodetection
@@ -387,27 +885,25 @@ DISTRIB_DESCRIPTION="Ubuntu 22.04.5 LTS"
 	return nil
 }
 
-// exportContainerAsTarGz exports the LXC container as a Proxmox-compatible tar.gz template
-func exportContainerAsTarGz(l *LXCBuilder, workDir, containerPath string) error {
-	// Determine container name from path
-	containerName := filepath.Base(containerPath)
-
-	// Check if this is a layered container (has parent)
-	if containerName != "ubuntu-base" {
-		return l.exportLayeredContainer(workDir, containerPath, containerName)
+// exportContainerAsTarGz exports the LXC container as a Proxmox-compatible tar.gz
+// template and returns the archive path and its SHA256. Layers, which have a
+// parentLayer, export only what they add to it.
+func exportContainerAsTarGz(l *LXCBuilder, workDir, containerPath, parentLayer string, layerPaths []string) (string, string, error) {
+	if parentLayer != "" {
+		return l.exportLayeredContainer(workDir, containerPath, filepath.Base(containerPath), parentLayer, layerPaths)
 	}
 
-	// Export base container normally
-	return l.exportBaseContainer(workDir, containerPath)
+	// Export base container normally, named after its spec
+	return l.exportBaseContainer(workDir, containerPath, l.Spec.String())
 }
 
-// exportBaseContainer exports the base Ubuntu container
-func (l *LXCBuilder) exportBaseContainer(workDir, containerPath string) error {
+// exportBaseContainer exports a full container as lxc-<archivePrefix>-<timestamp>.tar.gz
+func (l *LXCBuilder) exportBaseContainer(workDir, containerPath, archivePrefix string) (string, string, error) {
 	l.log("📦 Exporting base container as Proxmox-compatible tar.gz template...")
 
 	// Create tar.gz filename with timestamp
-	timestamp := time.Now().Format("20060102-150405")
-	tarGzName := fmt.Sprintf("lxc-ubuntu-base-%s.tar.gz", timestamp)
+	timestamp := l.Clock.Now().Format("20060102-150405")
+	tarGzName := fmt.Sprintf("lxc-%s-%s.tar.gz", archivePrefix, timestamp)
 	tarGzPath := filepath.Join(workDir, tarGzName)
 
 	// Get rootfs path for Proxmox-compatible export
@@ -415,20 +911,26 @@ func (l *LXCBuilder) exportBaseContainer(workDir, containerPath string) error {
 
 	// Create Proxmox metadata
 	if err := l.createProxmoxMetadata(rootfsPath); err != nil {
-		l.log("Warning: failed to create metadata: %v", err)
+		l.warn("Failed to create metadata: %v", err)
 	}
 
 	// Export only the rootfs as tar.gz (Proxmox format)
 	l.log("Creating Proxmox-compatible tar.gz archive: %s", tarGzPath)
-	if err := l.runCommand("tar", "-czf", tarGzPath, "-C", rootfsPath, "."); err != nil {
-		return fmt.Errorf("failed to create tar.gz archive: %w", err)
+	args := append(l.excludeArgs(), "-czf", tarGzPath, "-C", rootfsPath, ".")
+	if err := l.runCommand("tar", args...); err != nil {
+		return "", "", withStep("create tar.gz archive", err)
+	}
+
+	checksum, err := l.writeChecksum(tarGzPath)
+	if err != nil {
+		return "", "", err
 	}
 
 	// Also create a symlink with a consistent name
-	symlinkPath := filepath.Join(workDir, "lxc-ubuntu-latest.tar.gz")
+	symlinkPath := filepath.Join(workDir, fmt.Sprintf("lxc-%s-latest.tar.gz", archivePrefix))
 	os.Remove(symlinkPath) // Remove existing symlink if it exists
 	if err := os.Symlink(tarGzName, symlinkPath); err != nil {
-		l.log("Warning: failed to create symlink: %v", err)
+		l.warn("Failed to create symlink: %v", err)
 	}
 
 	l.log("✅ Proxmox-compatible container template exported!")
@@ -436,138 +938,134 @@ func (l *LXCBuilder) exportBaseContainer(workDir, containerPath string) error {
 	l.log("🔗 Latest symlink: %s", symlinkPath)
 	l.log("📋 Usage: Copy to /var/lib/vz/template/cache/ on Proxmox")
 
-	return nil
+	return tarGzPath, checksum, nil
 }
 
-// exportLayeredContainer exports only the differences from the parent layer
-func (l *LXCBuilder) exportLayeredContainer(workDir, containerPath, containerName string) error {
+// exportLayeredContainer exports only layerPaths, the files the layer adds on top of parentLayer
+func (l *LXCBuilder) exportLayeredContainer(workDir, containerPath, containerName, parentLayer string, layerPaths []string) (string, string, error) {
 	l.log("📦 Exporting layered container with diff-only approach...")
 
-	// Determine parent layer (for java8, parent is ubuntu-base)
-	parentLayer := "ubuntu-base"
 	parentPath := filepath.Join(l.ContainerDir, parentLayer)
 
 	if !l.dirExists(parentPath) {
-		l.log("Warning: Parent layer not found, exporting full container")
-		return l.exportBaseContainer(workDir, containerPath)
+		l.warn("Parent layer not found, exporting full container")
+		return l.exportBaseContainer(workDir, containerPath, containerName)
 	}
 
 	// Create tar.gz filename with timestamp
-	timestamp := time.Now().Format("20060102-150405")
+	timestamp := l.Clock.Now().Format("20060102-150405")
 	tarGzName := fmt.Sprintf("lxc-%s-layer-%s.tar.gz", containerName, timestamp)
 	tarGzPath := filepath.Join(workDir, tarGzName)
 
 	// Get rootfs paths
 	containerRootfs := filepath.Join(containerPath, "rootfs")
 
-	// Create layer diff using a simple approach: tar specific Java directories
-	l.log("🔍 Creating layer diff archive with Java-specific files...")
-
-	// Check which files exist before adding to tar
-	filesToTar := []string{}
-	possibleFiles := []string{"usr/lib/jvm", "etc/environment", "etc/bash.bashrc", "usr/bin/java", "usr/bin/javac"}
-
-	for _, file := range possibleFiles {
-		fullPath := filepath.Join(containerRootfs, file)
-		if _, err := os.Stat(fullPath); err == nil {
-			filesToTar = append(filesToTar, file)
-			l.log("✓ Found: %s", file)
-		} else {
-			l.log("✗ Missing: %s", file)
+	var sourceArgs []string
+	if l.isOverlayLayer(containerPath) {
+		// The overlay upper dir holds exactly what the layer changed
+		l.log("🔍 Creating layer diff archive from the overlay upper dir...")
+		sourceArgs = append(l.excludeArgs(), "-C", filepath.Join(containerPath, overlayUpperDir), ".")
+	} else {
+		var err error
+		if sourceArgs, err = l.declaredLayerArgs(containerRootfs, containerName, layerPaths); err != nil {
+			return "", "", err
 		}
 	}
 
-	if len(filesToTar) == 0 {
-		return fmt.Errorf("no Java files found in container - Java installation may have failed")
+	args := append([]string{"-czf", tarGzPath}, sourceArgs...)
+	if err := l.runCommand("tar", args...); err != nil {
+		return "", "", withStep("create layer diff archive", err)
 	}
 
-	// Create tar with only the existing files
-	args := []string{"-czf", tarGzPath, "-C", containerRootfs}
-	args = append(args, filesToTar...)
-
-	if err := l.runCommand("tar", args...); err != nil {
-		return fmt.Errorf("failed to create layer diff archive: %w", err)
+	checksum, err := l.writeChecksum(tarGzPath)
+	if err != nil {
+		return "", "", err
 	}
 
 	// Also create a symlink with a consistent name
 	symlinkPath := filepath.Join(workDir, fmt.Sprintf("lxc-%s-layer-latest.tar.gz", containerName))
 	os.Remove(symlinkPath) // Remove existing symlink if it exists
 	if err := os.Symlink(tarGzName, symlinkPath); err != nil {
-		l.log("Warning: failed to create symlink: %v", err)
+		l.warn("Failed to create symlink: %v", err)
 	}
 
 	// Create layer metadata
-	l.createLayerMetadata(workDir, containerName, parentLayer, tarGzName)
+	if err := l.createLayerMetadata(workDir, containerName, parentLayer, tarGzName, checksum); err != nil {
+		return "", "", err
+	}
 
 	l.log("✅ Layer diff exported successfully!")
 	l.log("📁 Archive location: %s", tarGzPath)
 	l.log("🔗 Latest symlink: %s", symlinkPath)
 	l.log("📋 This layer contains only changes from %s", parentLayer)
 
-	return nil
+	return tarGzPath, checksum, nil
 }
 
-// createLayerMetadata creates metadata for the layer
-func (l *LXCBuilder) createLayerMetadata(workDir, layerName, parentLayer, archiveName string) {
-	metadata := fmt.Sprintf(`{
-  "layer_name": "%s",
-  "parent_layer": "%s", 
-  "archive": "%s",
-  "created": "%s",
-  "type": "diff_layer"
-}`, layerName, parentLayer, archiveName, time.Now().Format(time.RFC3339))
+// declaredLayerArgs returns tar arguments for the declared layer paths that exist in the rootfs
+func (l *LXCBuilder) declaredLayerArgs(containerRootfs, containerName string, layerPaths []string) ([]string, error) {
+	l.log("🔍 Creating layer diff archive from %d declared paths...", len(layerPaths))
 
-	metadataPath := filepath.Join(workDir, fmt.Sprintf("%s-layer.json", layerName))
-	os.WriteFile(metadataPath, []byte(metadata), 0644)
-	l.log("📄 Layer metadata: %s", metadataPath)
+	// Check which files exist before adding to tar
+	filesToTar := []string{}
+	for _, file := range layerPaths {
+		fullPath, err := helper.SafeJoin(containerRootfs, file)
+		if err != nil {
+			return nil, fmt.Errorf("invalid layer path: %w", err)
+		}
+		if _, err := os.Stat(fullPath); err == nil {
+			filesToTar = append(filesToTar, file)
+			l.log("✓ Found: %s", file)
+		} else {
+			l.log("✗ Missing: %s", file)
+		}
+	}
+
+	if len(filesToTar) == 0 {
+		return nil, fmt.Errorf("none of the layer paths %v exist in %s - the layer setup may have failed", layerPaths, containerName)
+	}
+
+	return append([]string{"-C", containerRootfs}, filesToTar...), nil
 }
 
-// RunJava8Container creates a Java 8 layer on top of Ubuntu base
-func RunJava8Container() {
-	buildLayeredContainer("ubuntu-java8", "ubuntu-base", buildJava8Layer)
+// layerMetadata is written next to a layer archive as <layer>-layer.json
+type layerMetadata struct {
+	LayerName   string `json:"layer_name"`
+	ParentLayer string `json:"parent_layer"`
+	Archive     string `json:"archive"`
+	SHA256      string `json:"sha256"`
+	Created     string `json:"created"`
+	Type        string `json:"type"`
 }
 
-// buildLayeredContainer creates a container layer, optionally based on a parent layer
-func buildLayeredContainer(containerName, parentLayer string, buildFunc func(*LXCBuilder, string, string) error) {
-	pwd, err := os.Getwd()
+// createLayerMetadata writes the layer's metadata file to workDir
+func (l *LXCBuilder) createLayerMetadata(workDir, layerName, parentLayer, archiveName, checksum string) error {
+	metadata, err := json.Marshal(layerMetadata{
+		LayerName:   layerName,
+		ParentLayer: parentLayer,
+		Archive:     archiveName,
+		SHA256:      checksum,
+		Created:     l.Clock.Now().Format(time.RFC3339),
+		Type:        "diff_layer",
+	})
 	if err != nil {
-		fmt.Printf("Failed to get current directory: %v\n", err)
-		return
+		return withStep("encode layer metadata", err)
 	}
 
-	workDir := filepath.Join(pwd, "sandbox", "build", "lxc-ubuntu")
-	containerDir := filepath.Join(workDir, "containers")
-
-	// Create directories
-	if err := os.MkdirAll(workDir, 0755); err != nil {
-		fmt.Printf("Failed to create work directory: %v\n", err)
-		return
-	}
-	if err := os.MkdirAll(containerDir, 0755); err != nil {
-		fmt.Printf("Failed to create container directory: %v\n", err)
-		return
+	metadataPath, err := helper.SafeJoin(workDir, fmt.Sprintf("%s-layer.json", layerName))
+	if err != nil {
+		return withStep("write layer metadata", err)
 	}
-
-	fmt.Printf("🏗️  Work directory: %s\n", workDir)
-	fmt.Printf("🐳 Container directory: %s\n", containerDir)
-
-	builder := NewLXCBuilder(workDir, containerDir)
-	defer builder.Close()
-
-	if err := buildFunc(builder, containerName, parentLayer); err != nil {
-		fmt.Printf("Container build failed: %v\n", err)
-		return
+	if err := os.WriteFile(metadataPath, metadata, 0644); err != nil {
+		return withStep("write layer metadata", err)
 	}
+	l.log("📄 Layer metadata: %s", metadataPath)
+	return nil
+}
 
-	fmt.Printf("✅ %s container created successfully!\n", containerName)
-	containerPath := filepath.Join(containerDir, containerName)
-	fmt.Printf("📁 Container location: %s\n", containerPath)
-
-	// Export container as tar.gz
-	if err := exportContainerAsTarGz(builder, workDir, containerPath); err != nil {
-		fmt.Printf("Container export failed: %v\n", err)
-		return
-	}
+// RunJava8Container creates a Java 8 layer on top of Ubuntu base
+func RunJava8Container(ctx context.Context, opts BuildOptions) (*BuildResult, error) {
+	return BuildContainer(ctx, "ubuntu-java8", opts)
 }
 
 // dirExists checks if a directory exists
@@ -580,14 +1078,31 @@ func (l *LXCBuilder) dirExists(path string) bool {
 
 // createLayerFromParent creates a new container by copying from parent
 func (l *LXCBuilder) createLayerFromParent(containerName, parentLayer string) error {
-	l.log("📋 Copying from parent layer: %s", parentLayer)
+	parentPath, err := helper.SafeJoin(l.ContainerDir, parentLayer)
+	if err != nil {
+		return err
+	}
+	newPath, err := helper.SafeJoin(l.ContainerDir, containerName)
+	if err != nil {
+		return err
+	}
 
-	parentPath := filepath.Join(l.ContainerDir, parentLayer)
-	newPath := filepath.Join(l.ContainerDir, containerName)
+	if l.LayerStrategy == OverlayStrategy {
+		if overlayAvailable() {
+			return l.createOverlayLayer(parentPath, newPath, containerName)
+		}
+		l.warn("Overlayfs is not available, falling back to copying the parent layer")
+	}
+
+	l.log("📋 Copying from parent layer: %s", parentLayer)
+	if err := l.mountOverlay(parentPath); err != nil {
+		return err
+	}
 
-	// Copy the entire parent container directory
-	if err := l.runCommand("cp", "-r", parentPath, newPath); err != nil {
-		return fmt.Errorf("failed to copy parent layer: %w", err)
+	// Copy the entire parent container directory into the one reserved for the
+	// build; -T keeps cp from nesting it inside
+	if err := l.runCommand("cp", "-rT", parentPath, newPath); err != nil {
+		return withStep("copy parent layer", err)
 	}
 
 	// Update the container name in config
@@ -604,12 +1119,19 @@ func (l *LXCBuilder) createLayerFromParent(containerName, parentLayer string) er
 }
 
 // buildJava8Layer creates Java 8 layer on top of Ubuntu base
-func buildJava8Layer(l *LXCBuilder, containerName, parentLayer string) error {
+func buildJava8Layer(l *LXCBuilder, containerName, parentLayer string) (err error) {
+	defer func() {
+		if err != nil {
+			l.discardFailedBuild(containerName)
+		}
+	}()
+
 	l.log("🍵 FROM %s - Creating Java 8 layer...", parentLayer)
 
 	// Clean up any existing container
 	l.log("Cleaning up any existing container: %s", containerName)
 	l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+	l.unmountOverlay(filepath.Join(l.ContainerDir, containerName))
 	l.runCommand("lxc-destroy", "-n", containerName, "-P", l.ContainerDir)
 
 	// Create snapshot from parent layer if it exists
@@ -621,8 +1143,9 @@ func buildJava8Layer(l *LXCBuilder, containerName, parentLayer string) error {
 		}
 	} else {
 		// Create fresh container if no parent
-		if err := l.runCommand("lxc-create", "-t", "download", "-n", containerName, "-P", l.ContainerDir, "--", "--dist", "ubuntu", "--release", "jammy", "--arch", "amd64"); err != nil {
-			return fmt.Errorf("failed to create LXC container: %w", err)
+		createArgs := append([]string{"-t", "download", "-n", containerName, "-P", l.ContainerDir, "--"}, l.Spec.createArgs()...)
+		if err := l.runCommandWithRetry(createAttempts, createRetryBackoff, "lxc-create", createArgs...); err != nil {
+			return withStep("create LXC container", err)
 		}
 	}
 
@@ -630,25 +1153,23 @@ func buildJava8Layer(l *LXCBuilder, containerName, parentLayer string) error {
 	rootfsPath := filepath.Join(l.ContainerDir, containerName, "rootfs")
 	l.log("🔧 Container rootfs location: %s", rootfsPath)
 
-	// Create Java 8 installation script
-	scriptPath := filepath.Join(l.ContainerDir, "java8-setup.sh")
+	if err := l.configureAptMirror(rootfsPath); err != nil {
+		return err
+	}
+
+	// Create Java 8 installation script, kept in the container's own directory
+	scriptPath := filepath.Join(l.ContainerDir, containerName, "java8-setup.sh")
 	setupScript := `#!/bin/bash
 # Removed set -e to continue on errors and debug issues
 
 # Setup DNS resolution first
 echo "🌐 Setting up DNS resolution..."
-cat > /etc/resolv.conf << 'EOF'
-nameserver 8.8.8.8
-nameserver 8.8.4.4
-nameserver 1.1.1.1
-nameserver 1.0.0.1
-EOF
-
-echo "✅ DNS configuration written"
+` + l.dnsScript() + `
+echo "✅ DNS configuration done"
 
 # Test DNS resolution  
 echo "🔍 Testing DNS resolution..."
-if nslookup archive.ubuntu.com; then
+if nslookup ` + l.Spec.archiveHost() + `; then
     echo "✅ DNS resolution working"
 else
     echo "⚠️ DNS test failed, but continuing..."
@@ -695,7 +1216,7 @@ which java || echo "Java binary not found in PATH"
 ls -la /usr/lib/jvm/ || echo "JVM directory not found"
 
 # Set JAVA_HOME environment variable
-JAVA_HOME_PATH="/usr/lib/jvm/java-8-openjdk-amd64"
+JAVA_HOME_PATH="/usr/lib/jvm/java-8-openjdk-` + l.Spec.Arch + `"
 if [ -d "$JAVA_HOME_PATH" ]; then
     echo "🔧 ENV JAVA_HOME=$JAVA_HOME_PATH"
     echo "JAVA_HOME=$JAVA_HOME_PATH" >> /etc/environment
@@ -739,62 +1260,68 @@ echo "✅ Java 8 layer setup completed successfully"
 	// Start the container and install Java 8
 	l.log("🚀 Starting container for Java 8 installation...")
 	if err := l.runCommand("lxc-start", "-n", containerName, "-P", l.ContainerDir, "-d"); err != nil {
-		l.log("Warning: failed to start container, falling back to chroot")
+		l.warn("Failed to start container, falling back to chroot")
 		return l.fallbackToChroot(rootfsPath, scriptPath)
 	}
 
 	// Wait for container to be ready
 	l.log("⏳ Waiting for container to be ready...")
-	for i := 0; i < 30; i++ {
-		if err := l.runCommand("lxc-info", "-n", containerName, "-P", l.ContainerDir, "-s"); err == nil {
-			break
-		}
-		time.Sleep(1 * time.Second)
+	if err := l.waitForRunning(containerName); err != nil {
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		return err
 	}
 
 	// Setup DNS immediately in the running container
-	l.log("🌐 Setting up DNS in running container...")
-	dnsSetupCmd := `echo 'nameserver 8.8.8.8
-nameserver 8.8.4.4
-nameserver 1.1.1.1
-nameserver 1.0.0.1' > /etc/resolv.conf`
-	if err := l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "/bin/bash", "-c", dnsSetupCmd); err != nil {
-		l.log("Warning: failed to setup DNS in container: %v", err)
+	if len(l.DNSServers) > 0 {
+		l.log("🌐 Setting up DNS in running container...")
+		if err := l.runCommand("lxc-attach", l.attachArgs(containerName, "/bin/bash", "-c", l.dnsScript())...); err != nil {
+			l.warn("Failed to setup DNS in container: %v", err)
+		}
 	}
 
 	// Copy script into container
 	containerScriptPath := filepath.Join(rootfsPath, "java8-setup.sh")
 	if err := l.runCommand("cp", scriptPath, containerScriptPath); err != nil {
 		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
-		return fmt.Errorf("failed to copy setup script: %w", err)
+		return withStep("copy setup script", err)
 	}
 
 	// Execute script inside running container
 	l.log("🔧 Running Java 8 setup script in container...")
-	if err := l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "/bin/bash", "/java8-setup.sh"); err != nil {
+	if err := l.runCommand("lxc-attach", l.attachArgs(containerName, "/bin/bash", "/java8-setup.sh")...); err != nil {
 		l.log("Error: Java 8 setup script execution failed: %v", err)
 
 		// Get more detailed error information
 		l.log("Getting script output for debugging...")
-		l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "cat", "/java8-setup.sh")
+		l.runCommand("lxc-attach", l.attachArgs(containerName, "cat", "/java8-setup.sh")...)
 
 		l.log("Checking container network connectivity...")
-		l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "ping", "-c", "1", "8.8.8.8")
+		if len(l.DNSServers) > 0 {
+			l.runCommand("lxc-attach", l.attachArgs(containerName, "ping", "-c", "1", l.DNSServers[0])...)
+		}
 
 		l.log("Checking DNS resolution...")
-		l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "nslookup", "archive.ubuntu.com")
+		l.runCommand("lxc-attach", l.attachArgs(containerName, "nslookup", l.Spec.archiveHost())...)
 
 		l.log("Checking apt sources...")
-		l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "cat", "/etc/apt/sources.list")
+		l.runCommand("lxc-attach", l.attachArgs(containerName, "cat", "/etc/apt/sources.list")...)
 
-		// Continue with container creation even if Java install fails
-		l.log("Warning: Java installation failed, but continuing with container creation...")
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		l.cleanupMounts(rootfsPath)
+		return withStep("run Java 8 setup script", err)
 	}
 
 	// Verify Java installation
 	l.log("🔍 Verifying Java installation...")
-	if err := l.runCommand("lxc-attach", "-n", containerName, "-P", l.ContainerDir, "--", "java", "-version"); err != nil {
-		l.log("Warning: Java verification failed: %v", err)
+	if err := l.runCommand("lxc-attach", l.attachArgs(containerName, "java", "-version")...); err != nil {
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		l.cleanupMounts(rootfsPath)
+		return withStep("verify Java installation", err)
+	}
+	if err := l.runSteps(containerName); err != nil {
+		l.runCommand("lxc-stop", "-n", containerName, "-P", l.ContainerDir)
+		l.cleanupMounts(rootfsPath)
+		return err
 	}
 
 	// Stop the container
@@ -0,0 +1,489 @@
+package images
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeCommands records the commands a build runs in place of execCommand
+type fakeCommands struct {
+	lines []string
+	mu    sync.Mutex
+}
+
+// stubCommands replaces execCommand for the rest of the test. run decides how
+// each command behaves and may return nil to have it succeed silently.
+func stubCommands(t *testing.T, run func(ctx context.Context, name string, args []string) *exec.Cmd) *fakeCommands {
+	t.Helper()
+	fake := &fakeCommands{}
+	previous := execCommand
+	execCommand = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+		fake.mu.Lock()
+		fake.lines = append(fake.lines, strings.TrimSpace(name+" "+strings.Join(args, " ")))
+		fake.mu.Unlock()
+
+		if run != nil {
+			if cmd := run(ctx, name, args); cmd != nil {
+				return cmd
+			}
+		}
+		return exec.CommandContext(ctx, "true")
+	}
+	t.Cleanup(func() { execCommand = previous })
+	return fake
+}
+
+// commands returns the command lines run so far
+func (f *fakeCommands) commands() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.lines)
+}
+
+// fakeOutput returns a command that prints output and exits with code
+func fakeOutput(ctx context.Context, output string, code int) *exec.Cmd {
+	return exec.CommandContext(ctx, "sh", "-c", `printf '%s' "$1"; exit "$2"`, "sh", output, strconv.Itoa(code))
+}
+
+// prepareDownloadedContainer lays out what lxc-create leaves behind for containerName
+func prepareDownloadedContainer(t *testing.T, l *LXCBuilder, containerName string) {
+	t.Helper()
+	containerPath := filepath.Join(l.ContainerDir, containerName)
+	writeRootfs(t, filepath.Join(containerPath, "rootfs"), "bin/bash", "etc/os-release")
+	if err := os.WriteFile(filepath.Join(containerPath, "config"), []byte("lxc.uts.name = "+containerName+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLogConcurrentWrites is meant for go test -race; without it only interleaving is caught
+func TestLogConcurrentWrites(t *testing.T) {
+	builder := newTestBuilder(t)
+	if builder.LogFile == nil {
+		t.Fatal("builder has no log file")
+	}
+	logPath := builder.LogFile.Name()
+
+	const workers, linesPerWorker = 16, 50
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < linesPerWorker; i++ {
+				builder.log("worker %d line %d %s", w, i, strings.Repeat("x", 200))
+			}
+		}()
+	}
+	wg.Wait()
+	builder.Close()
+
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != workers*linesPerWorker {
+		t.Fatalf("log has %d lines, want %d", len(lines), workers*linesPerWorker)
+	}
+	wellFormed := regexp.MustCompile(`^\[\d\d:\d\d:\d\d\] worker \d+ line \d+ x{200}$`)
+	for _, line := range lines {
+		if !wellFormed.MatchString(line) {
+			t.Fatalf("malformed log line %q", line)
+		}
+	}
+}
+
+func TestCleanupRestoresBuildContext(t *testing.T) {
+	for name, cleanup := range map[string]func(*LXCBuilder, string){
+		"abortBuild":         (*LXCBuilder).abortBuild,
+		"discardFailedBuild": (*LXCBuilder).discardFailedBuild,
+	} {
+		t.Run(name, func(t *testing.T) {
+			builder := newTestBuilder(t)
+			buildCtx, cancel := context.WithTimeout(context.Background(), -time.Second)
+			defer cancel()
+			builder.ctx = buildCtx
+
+			// The container does not exist, so every cleanup command fails harmlessly
+			cleanup(builder, "missing-container")
+
+			if builder.ctx != buildCtx {
+				t.Error("cleanup left its own context on the builder")
+			}
+		})
+	}
+}
+
+func TestCreateLayerMetadata(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.Clock = FixedClock{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	// A quote would have broken the hand-written JSON
+	if err := builder.createLayerMetadata(builder.WorkDir, "java8", `ubuntu "base"`, "lxc-java8-layer.tar.gz", "abc123"); err != nil {
+		t.Fatalf("createLayerMetadata() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(builder.WorkDir, "java8-layer.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metadata layerMetadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		t.Fatalf("metadata is not valid JSON: %v", err)
+	}
+	want := layerMetadata{
+		LayerName:   "java8",
+		ParentLayer: `ubuntu "base"`,
+		Archive:     "lxc-java8-layer.tar.gz",
+		SHA256:      "abc123",
+		Created:     "2024-01-02T03:04:05Z",
+		Type:        "diff_layer",
+	}
+	if metadata != want {
+		t.Errorf("metadata = %+v, want %+v", metadata, want)
+	}
+}
+
+func TestCreateLayerMetadataReportsWriteErrors(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	missingDir := filepath.Join(builder.WorkDir, "missing")
+	if err := builder.createLayerMetadata(missingDir, "java8", "ubuntu-base", "lxc-java8-layer.tar.gz", "abc123"); err == nil {
+		t.Error("createLayerMetadata() into a missing directory returned no error")
+	}
+}
+
+func TestAppendToConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+	}{
+		{"no trailing newline", "lxc.uts.name = web", "lxc.uts.name = web\nlxc.net.0.type = veth\n"},
+		{"trailing newline", "lxc.uts.name = web\n", "lxc.uts.name = web\nlxc.net.0.type = veth\n"},
+		{"empty file", "", "lxc.net.0.type = veth\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newTestBuilder(t)
+			configPath := filepath.Join(builder.ContainerDir, "config")
+			if err := os.WriteFile(configPath, []byte(tt.existing), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := builder.appendToConfig(configPath, "lxc.net.0.type = veth\n"); err != nil {
+				t.Fatalf("appendToConfig() error = %v", err)
+			}
+
+			content, err := os.ReadFile(configPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(content) != tt.want {
+				t.Errorf("config = %q, want %q", content, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendToConfigMissingFile(t *testing.T) {
+	builder := newTestBuilder(t)
+
+	if err := builder.appendToConfig(filepath.Join(builder.ContainerDir, "config"), "lxc.net.0.type = veth\n"); err == nil {
+		t.Error("appendToConfig() created a config that did not exist")
+	}
+}
+
+func TestExcludeArgs(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.ExcludePaths = []string{"var/cache/apt", "/var/log/", "tmp", "", ".", "/", "home/../srv"}
+
+	want := []string{"--exclude=./var/cache/apt/*", "--exclude=./var/log/*", "--exclude=./tmp/*", "--exclude=./srv/*"}
+	if got := builder.excludeArgs(); !slices.Equal(got, want) {
+		t.Errorf("excludeArgs() = %q, want %q", got, want)
+	}
+}
+
+// writeFixtureRootfs creates a container whose rootfs holds the given files
+func writeFixtureRootfs(t *testing.T, containerPath string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(containerPath, "rootfs", file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(file), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// archiveEntries lists the entries of a tar.gz archive
+func archiveEntries(t *testing.T, archivePath string) []string {
+	t.Helper()
+	file, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []string
+	reader := tar.NewReader(gz)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, strings.TrimSuffix(header.Name, "/"))
+	}
+}
+
+func TestExportBaseContainerExcludesPaths(t *testing.T) {
+	if _, err := exec.LookPath("tar"); err != nil {
+		t.Skip("tar is not installed")
+	}
+	builder := newTestBuilder(t)
+	containerPath := filepath.Join(builder.ContainerDir, "ubuntu-base")
+	writeFixtureRootfs(t, containerPath,
+		"etc/hostname",
+		"var/cache/apt/archives/curl.deb",
+		"var/cache/debconf/config.dat",
+		"var/log/syslog",
+		"tmp/scratch",
+	)
+
+	archivePath, _, err := builder.exportBaseContainer(builder.WorkDir, containerPath, "ubuntu-jammy-amd64")
+	if err != nil {
+		t.Fatalf("exportBaseContainer() error = %v", err)
+	}
+
+	entries := archiveEntries(t, archivePath)
+	for _, want := range []string{"./etc/hostname", "./var/cache/debconf/config.dat", "./var/cache/apt", "./var/log", "./tmp"} {
+		if !slices.Contains(entries, want) {
+			t.Errorf("archive is missing %s: %v", want, entries)
+		}
+	}
+	for _, excluded := range []string{"./var/cache/apt/archives", "./var/cache/apt/archives/curl.deb", "./var/log/syslog", "./tmp/scratch"} {
+		if slices.Contains(entries, excluded) {
+			t.Errorf("archive contains excluded %s", excluded)
+		}
+	}
+}
+
+func TestBuildUbuntuContainerKeepsScriptInContainerDir(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.DNSServers = nil
+	commands := stubCommands(t, func(ctx context.Context, name string, args []string) *exec.Cmd {
+		if name == "lxc-info" {
+			return fakeOutput(ctx, "State:          RUNNING\n", 0)
+		}
+		return nil
+	})
+	prepareDownloadedContainer(t, builder, "vm-a")
+
+	if err := buildUbuntuContainer(builder, "vm-a"); err != nil {
+		t.Fatalf("buildUbuntuContainer() error = %v", err)
+	}
+
+	scriptPath := filepath.Join(builder.ContainerDir, "vm-a", "setup.sh")
+	copyScript := "cp " + scriptPath + " " + filepath.Join(builder.ContainerDir, "vm-a", "rootfs", "setup.sh")
+	if !slices.Contains(commands.commands(), copyScript) {
+		t.Errorf("commands = %q, want %q", commands.commands(), copyScript)
+	}
+	if _, err := os.Stat(scriptPath); !os.IsNotExist(err) {
+		t.Errorf("setup script left behind: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(builder.ContainerDir, "setup.sh")); !os.IsNotExist(err) {
+		t.Errorf("setup script written to the shared container directory: %v", err)
+	}
+}
+
+func TestCheckDiskSpace(t *testing.T) {
+	tests := []struct {
+		free      map[string]uint64
+		statErr   error
+		name      string
+		wantError string
+	}{
+		{name: "enough", free: map[string]uint64{"work": 5 << 30, "containers": 5 << 30}},
+		{name: "exactly enough", free: map[string]uint64{"work": 4 << 30, "containers": 4 << 30}},
+		{name: "work dir full", free: map[string]uint64{"work": 3 << 30, "containers": 5 << 30}, wantError: "has 3072 MB free, build needs 4096 MB"},
+		{name: "container dir full", free: map[string]uint64{"work": 5 << 30, "containers": 1 << 20}, wantError: "has 1 MB free, build needs 4096 MB"},
+		{name: "statfs fails", statErr: errors.New("no such file or directory"), wantError: "failed to check disk space: no such file or directory"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newTestBuilder(t)
+			builder.WorkDir, builder.ContainerDir = "work", "containers"
+			builder.RequiredDiskBytes, builder.DiskHeadroomBytes = 3<<30, 1<<30
+
+			previous := freeDiskSpace
+			freeDiskSpace = func(dir string) (uint64, error) { return tt.free[dir], tt.statErr }
+			t.Cleanup(func() { freeDiskSpace = previous })
+
+			err := builder.checkDiskSpace()
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("checkDiskSpace() error = %v", err)
+				}
+				return
+			}
+			var provisionErr *ProvisionError
+			if !errors.As(err, &provisionErr) || provisionErr.Step != "check disk space" {
+				t.Fatalf("checkDiskSpace() error = %v, want a ProvisionError for the disk space check", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("checkDiskSpace() error = %q, want it to contain %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestExportWithFixedClockIsDeterministic(t *testing.T) {
+	if _, err := exec.LookPath("tar"); err != nil {
+		t.Skip("tar is not installed")
+	}
+	builder := newTestBuilder(t)
+	builder.Clock = FixedClock{Time: time.Date(2024, 3, 9, 14, 5, 7, 0, time.UTC)}
+
+	basePath := filepath.Join(builder.ContainerDir, "ubuntu-base-1a2b3c4d")
+	writeFixtureRootfs(t, basePath, "etc/hostname")
+	layerPath := filepath.Join(builder.ContainerDir, "ubuntu-java8-5e6f7a8b")
+	writeFixtureRootfs(t, layerPath, "usr/lib/jvm/java-8/bin/java")
+
+	archivePath, _, err := builder.exportBaseContainer(builder.WorkDir, basePath, "ubuntu-jammy-amd64")
+	if err != nil {
+		t.Fatalf("exportBaseContainer() error = %v", err)
+	}
+	if want := filepath.Join(builder.WorkDir, "lxc-ubuntu-jammy-amd64-20240309-140507.tar.gz"); archivePath != want {
+		t.Errorf("base archive = %s, want %s", archivePath, want)
+	}
+
+	layerArchive, _, err := builder.exportLayeredContainer(builder.WorkDir, layerPath, "ubuntu-java8-5e6f7a8b", "ubuntu-base-1a2b3c4d", []string{"usr/lib/jvm"})
+	if err != nil {
+		t.Fatalf("exportLayeredContainer() error = %v", err)
+	}
+	if want := filepath.Join(builder.WorkDir, "lxc-ubuntu-java8-5e6f7a8b-layer-20240309-140507.tar.gz"); layerArchive != want {
+		t.Errorf("layer archive = %s, want %s", layerArchive, want)
+	}
+
+	content, err := os.ReadFile(filepath.Join(builder.WorkDir, "ubuntu-java8-5e6f7a8b-layer.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var metadata layerMetadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		t.Fatal(err)
+	}
+	if metadata.Created != "2024-03-09T14:05:07Z" || metadata.Archive != filepath.Base(layerArchive) {
+		t.Errorf("metadata = %+v, want it created at the fixed time for %s", metadata, filepath.Base(layerArchive))
+	}
+}
+
+// steppingClock moves forward only when waited on, so waits end at once
+type steppingClock struct {
+	now   time.Time
+	waits []time.Duration
+}
+
+func (c *steppingClock) Now() time.Time {
+	return c.now
+}
+
+func (c *steppingClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestWaitForRunning(t *testing.T) {
+	tests := []struct {
+		name string
+		// states are the lxc-info answers in turn, the last one repeating;
+		// an empty state makes lxc-info fail
+		states    []string
+		wantError string
+		wantPolls int
+	}{
+		{name: "running at once", states: []string{"RUNNING"}, wantPolls: 1},
+		{name: "starts after two polls", states: []string{"STOPPED", "STARTING", "RUNNING"}, wantPolls: 3},
+		{name: "stays stopped", states: []string{"STOPPED"}, wantError: "vm-a still STOPPED after 3.5s", wantPolls: 5},
+		{name: "lxc-info fails", states: []string{""}, wantError: "vm-a still UNKNOWN after 3.5s", wantPolls: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builder := newTestBuilder(t)
+			builder.ReadyTimeout = 3500 * time.Millisecond
+			clock := &steppingClock{now: time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)}
+			builder.Clock = clock
+
+			polls := 0
+			stubCommands(t, func(ctx context.Context, name string, args []string) *exec.Cmd {
+				state := tt.states[min(polls, len(tt.states)-1)]
+				polls++
+				if state == "" {
+					return fakeOutput(ctx, "vm-a doesn't exist", 1)
+				}
+				return fakeOutput(ctx, "State:          "+state+"\n", 0)
+			})
+
+			err := builder.waitForRunning("vm-a")
+			if polls != tt.wantPolls {
+				t.Errorf("polled %d times, want %d", polls, tt.wantPolls)
+			}
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("waitForRunning() error = %v", err)
+				}
+				return
+			}
+			var provisionErr *ProvisionError
+			if !errors.As(err, &provisionErr) || provisionErr.Step != "wait for container" || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("waitForRunning() error = %v, want %q", err, tt.wantError)
+			}
+			// The last wait is cut short at the timeout
+			want := []time.Duration{time.Second, time.Second, time.Second, 500 * time.Millisecond}
+			if !slices.Equal(clock.waits, want) {
+				t.Errorf("waits = %v, want %v", clock.waits, want)
+			}
+		})
+	}
+}
+
+func TestWaitForRunningStopsOnCancel(t *testing.T) {
+	builder := newTestBuilder(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	builder.ctx = ctx
+	// The command runs outside the cancelled build context so only the wait sees it
+	stubCommands(t, func(context.Context, string, []string) *exec.Cmd {
+		return fakeOutput(context.Background(), "State: STOPPED\n", 0)
+	})
+
+	if err := builder.waitForRunning("vm-a"); !errors.Is(err, context.Canceled) {
+		t.Errorf("waitForRunning() error = %v, want context.Canceled", err)
+	}
+}
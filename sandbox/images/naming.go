@@ -0,0 +1,193 @@
+package images
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// BuildSpec is the set of inputs that decide what a build produces
+type BuildSpec struct {
+	Container ContainerSpec
+	// Parent is the layer the build starts from, empty for base images
+	Parent    string
+	AptMirror string
+	// LayerPaths are the paths a layer build exports
+	LayerPaths []string
+}
+
+// hash returns a short stable digest of the spec
+func (s BuildSpec) hash() string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		s.Container.String(),
+		s.Parent,
+		s.AptMirror,
+		strings.Join(s.LayerPaths, ","),
+	}, "|")))
+	return hex.EncodeToString(sum[:4])
+}
+
+// buildName is the deterministic name for base built from spec, e.g. ubuntu-base-1a2b3c4d
+func buildName(base string, spec BuildSpec) string {
+	return base + "-" + spec.hash()
+}
+
+// buildNamePattern matches the part buildName and reserveBuildName add to a base name
+var buildNamePattern = regexp.MustCompile(`^-[0-9a-f]{8}(-[0-9]+)?$`)
+
+// buildOf returns the containerBuilds entry a container was built from, going
+// by its name; names from before GenerateBuildName match their base exactly
+func buildOf(containerName string) (containerBuild, bool) {
+	for base, build := range containerBuilds {
+		if containerName == base {
+			return build, true
+		}
+		if rest, ok := strings.CutPrefix(containerName, base); ok && buildNamePattern.MatchString(rest) {
+			return build, true
+		}
+	}
+	return containerBuild{}, false
+}
+
+// maxNameSuffix bounds the search for a free name
+const maxNameSuffix = 100
+
+// ErrNoFreeBuildName is returned when a build name and all its suffixes are taken
+var ErrNoFreeBuildName = errors.New("no free build name")
+
+// archiveExists reports whether an exported archive already uses name
+func archiveExists(workDir, name string) bool {
+	// Archives are named lxc-<name>-<YYYYMMDD-HHMMSS>.tar.gz or lxc-<name>-layer-<timestamp>.tar.gz
+	for _, pattern := range []string{
+		fmt.Sprintf("lxc-%s-[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]-*.tar.gz", name),
+		fmt.Sprintf("lxc-%s-layer-*.tar.gz", name),
+	} {
+		if matches, _ := filepath.Glob(filepath.Join(workDir, pattern)); len(matches) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// reserveBuildName claims buildName(base, spec), suffixed with -2, -3, ... when
+// taken, by creating its directory in containerDir. Creating the directory is
+// atomic, so concurrent builds never get the same name. Names an exported
+// archive still uses are skipped.
+func reserveBuildName(workDir, containerDir, base string, spec BuildSpec) (string, error) {
+	name := buildName(base, spec)
+	for suffix := 1; suffix <= maxNameSuffix; suffix++ {
+		candidate := name
+		if suffix > 1 {
+			candidate = fmt.Sprintf("%s-%d", name, suffix)
+		}
+		if archiveExists(workDir, candidate) {
+			continue
+		}
+		err := os.Mkdir(filepath.Join(containerDir, candidate), 0755)
+		if err == nil {
+			return candidate, nil
+		}
+		if !os.IsExist(err) {
+			return "", fmt.Errorf("failed to reserve build name %s: %w", candidate, err)
+		}
+	}
+	return "", fmt.Errorf("%w for %s: suffixes up to %d are taken", ErrNoFreeBuildName, name, maxNameSuffix)
+}
+
+// GenerateBuildName names a build of base from spec and reserves the name in
+// the default build directory. The same spec always yields the same name
+// unless a container or archive already uses it, in which case a numeric
+// suffix is added.
+func GenerateBuildName(base string, spec BuildSpec) (string, error) {
+	workDir, containerDir, err := buildDirs()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(containerDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create container directory: %w", err)
+	}
+	return reserveBuildName(workDir, containerDir, base, spec)
+}
+
+// buildNames returns every name a build of base from spec may have, unsuffixed first
+func buildNames(base string, spec BuildSpec) []string {
+	name := buildName(base, spec)
+	names := []string{name}
+	for suffix := 2; suffix <= maxNameSuffix; suffix++ {
+		names = append(names, fmt.Sprintf("%s-%d", name, suffix))
+	}
+	return names
+}
+
+// latestBuildName returns the newest existing container built from spec and
+// true, or buildName(base, spec) and false when there is none. Directories
+// without a config are reservations of builds that have not created their
+// container yet.
+func latestBuildName(containerDir, base string, spec BuildSpec) (string, bool) {
+	latest, found := buildName(base, spec), false
+	for _, candidate := range buildNames(base, spec) {
+		if _, err := os.Stat(filepath.Join(containerDir, candidate, "config")); err == nil {
+			latest, found = candidate, true
+		}
+	}
+	return latest, found
+}
+
+// overlayLowerLayers returns the names of the containers overlay layers in
+// containerDir are mounted on
+func overlayLowerLayers(containerDir string) map[string]bool {
+	lower := make(map[string]bool)
+	matches, _ := filepath.Glob(filepath.Join(containerDir, "*", overlayOptionsFile))
+	for _, path := range matches {
+		options, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, option := range strings.Split(string(options), ",") {
+			if dir, ok := strings.CutPrefix(option, "lowerdir="); ok {
+				lower[filepath.Base(filepath.Dir(dir))] = true
+			}
+		}
+	}
+	return lower
+}
+
+// removeSupersededBuilds destroys the other containers built from the same
+// spec as current, which a rebuild replaces. Their archives are kept, and so
+// are containers still running, e.g. as a VM, and those an overlay layer is
+// mounted on.
+func (l *LXCBuilder) removeSupersededBuilds(base string, spec BuildSpec, current string) {
+	lower := overlayLowerLayers(l.ContainerDir)
+	for _, name := range buildNames(base, spec) {
+		if name == current || lower[name] {
+			continue
+		}
+		containerPath := filepath.Join(l.ContainerDir, name)
+		if _, err := os.Stat(filepath.Join(containerPath, "config")); err != nil {
+			continue
+		}
+		if state, err := containerState(l, name); err != nil || state != "STOPPED" {
+			continue
+		}
+
+		l.log("🧹 Removing superseded build: %s", name)
+		l.unmountOverlay(containerPath)
+		if err := l.runCommand("lxc-destroy", "-n", name, "-P", l.ContainerDir); err != nil {
+			l.warn("Failed to remove superseded build %s: %v", name, err)
+		}
+	}
+}
+
+// buildSpec returns the BuildSpec of a build with o of an image on parent
+func (o BuildOptions) buildSpec(parent string, layerPaths []string) BuildSpec {
+	spec := BuildSpec{Container: defaultContainerSpec, Parent: parent, AptMirror: o.AptMirror, LayerPaths: layerPaths}
+	if o.Spec != (ContainerSpec{}) {
+		spec.Container = o.Spec
+	}
+	return spec
+}
@@ -0,0 +1,201 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBuildNameIsDeterministic(t *testing.T) {
+	spec := BuildSpec{Container: defaultContainerSpec, AptMirror: "http://mirror.local/ubuntu"}
+
+	name := buildName("ubuntu-base", spec)
+	if name != buildName("ubuntu-base", spec) {
+		t.Error("the same spec produced different names")
+	}
+	if !strings.HasPrefix(name, "ubuntu-base-") {
+		t.Errorf("buildName() = %q, want it prefixed by the base", name)
+	}
+
+	spec.AptMirror = ""
+	if buildName("ubuntu-base", spec) == name {
+		t.Error("a different spec produced the same name")
+	}
+}
+
+func TestReserveBuildNameAvoidsCollisions(t *testing.T) {
+	workDir := t.TempDir()
+	containerDir := t.TempDir()
+	spec := BuildSpec{Container: defaultContainerSpec}
+	name := buildName("ubuntu-base", spec)
+
+	got, err := reserveBuildName(workDir, containerDir, "ubuntu-base", spec)
+	if err != nil || got != name {
+		t.Fatalf("reserveBuildName() = %q, %v, want %q", got, err, name)
+	}
+	if _, err := os.Stat(filepath.Join(containerDir, name)); err != nil {
+		t.Errorf("reserved name has no container directory: %v", err)
+	}
+
+	// The reservation itself takes the name
+	if got, err := reserveBuildName(workDir, containerDir, "ubuntu-base", spec); err != nil || got != name+"-2" {
+		t.Errorf("reserveBuildName() after a reservation = %q, %v, want %q", got, err, name+"-2")
+	}
+
+	// An exported archive claims the name as well
+	archive := filepath.Join(workDir, "lxc-"+name+"-3-layer-20240101-000000.tar.gz")
+	if err := os.WriteFile(archive, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := reserveBuildName(workDir, containerDir, "ubuntu-base", spec); err != nil || got != name+"-4" {
+		t.Errorf("reserveBuildName() with an archive = %q, %v, want %q", got, err, name+"-4")
+	}
+}
+
+func TestReserveBuildNameIsExclusive(t *testing.T) {
+	workDir := t.TempDir()
+	containerDir := t.TempDir()
+	spec := BuildSpec{Container: defaultContainerSpec}
+
+	const builds = 20
+	names := make(chan string, builds)
+	var wg sync.WaitGroup
+	for range builds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name, err := reserveBuildName(workDir, containerDir, "ubuntu-base", spec)
+			if err != nil {
+				t.Error(err)
+			}
+			names <- name
+		}()
+	}
+	wg.Wait()
+	close(names)
+
+	seen := make(map[string]bool)
+	for name := range names {
+		if seen[name] {
+			t.Errorf("%s was reserved twice", name)
+		}
+		seen[name] = true
+	}
+}
+
+func TestReserveBuildNameExhausted(t *testing.T) {
+	containerDir := t.TempDir()
+	spec := BuildSpec{Container: defaultContainerSpec}
+	for _, name := range buildNames("ubuntu-base", spec) {
+		if err := os.Mkdir(filepath.Join(containerDir, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if name, err := reserveBuildName(t.TempDir(), containerDir, "ubuntu-base", spec); !errors.Is(err, ErrNoFreeBuildName) {
+		t.Errorf("reserveBuildName() = %q, %v, want ErrNoFreeBuildName", name, err)
+	}
+}
+
+func TestLatestBuildName(t *testing.T) {
+	containerDir := t.TempDir()
+	spec := BuildSpec{Container: defaultContainerSpec}
+	name := buildName("ubuntu-base", spec)
+
+	if got, found := latestBuildName(containerDir, "ubuntu-base", spec); found || got != name {
+		t.Errorf("latestBuildName() with no builds = %q, %t, want %q, false", got, found, name)
+	}
+
+	for _, dir := range []string{name, name + "-2"} {
+		writeRootfs(t, filepath.Join(containerDir, dir), "config")
+	}
+	// A reservation is not a build yet
+	if err := os.Mkdir(filepath.Join(containerDir, name+"-3"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if got, found := latestBuildName(containerDir, "ubuntu-base", spec); !found || got != name+"-2" {
+		t.Errorf("latestBuildName() = %q, %t, want %q, true", got, found, name+"-2")
+	}
+}
+
+func TestRemoveSupersededBuilds(t *testing.T) {
+	l := newTestBuilder(t)
+	spec := BuildSpec{Container: defaultContainerSpec}
+	name := buildName("ubuntu-base", spec)
+	running, lower, stale, current := name, name+"-2", name+"-3", name+"-4"
+	for _, container := range []string{running, lower, stale, current} {
+		prepareDownloadedContainer(t, l, container)
+	}
+	// An overlay layer built on lower keeps it in use
+	layer := filepath.Join(l.ContainerDir, "ubuntu-java8-layer")
+	options := "lowerdir=" + filepath.Join(l.ContainerDir, lower, "rootfs") + ",upperdir=x,workdir=y"
+	writeRootfs(t, layer)
+	if err := os.WriteFile(filepath.Join(layer, overlayOptionsFile), []byte(options), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := containerState
+	containerState = func(_ *LXCBuilder, containerName string) (string, error) {
+		if containerName == running {
+			return "RUNNING", nil
+		}
+		return "STOPPED", nil
+	}
+	t.Cleanup(func() { containerState = previous })
+	commands := stubCommands(t, nil)
+
+	l.removeSupersededBuilds("ubuntu-base", spec, current)
+
+	want := []string{"lxc-destroy -n " + stale + " -P " + l.ContainerDir}
+	if got := commands.commands(); !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+}
+
+func TestBuildOf(t *testing.T) {
+	spec := BuildSpec{Container: defaultContainerSpec}
+	tests := []struct {
+		name       string
+		wantParent string
+		wantOK     bool
+	}{
+		{buildName("ubuntu-base", spec), "", true},
+		{buildName("ubuntu-base", spec) + "-3", "", true},
+		{buildName("ubuntu-java8", spec), "ubuntu-base", true},
+		{"ubuntu-java8", "ubuntu-base", true},
+		{"ubuntu-base-custom", "", false},
+		{"web-1", "", false},
+	}
+	for _, tt := range tests {
+		build, ok := buildOf(tt.name)
+		if ok != tt.wantOK || build.parent != tt.wantParent {
+			t.Errorf("buildOf(%q) = parent %q, %t, want %q, %t", tt.name, build.parent, ok, tt.wantParent, tt.wantOK)
+		}
+	}
+}
+
+func TestFailedBuildReleasesReservedName(t *testing.T) {
+	stubBuildEnvironment(t)
+	stubCommands(t, nil)
+
+	build := containerBuild{
+		build: func(*LXCBuilder, string, string) error { return errors.New("lxc-create failed") },
+	}
+	result, err := runContainerBuild(context.Background(), "app", build, BuildOptions{})
+	if err == nil {
+		t.Fatal("runContainerBuild() succeeded for a failing build")
+	}
+
+	_, containerDir, err := buildDirs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(containerDir, result.ContainerName)); !os.IsNotExist(err) {
+		t.Errorf("reservation of %s survived the failed build: %v", result.ContainerName, err)
+	}
+}
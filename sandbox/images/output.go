@@ -0,0 +1,44 @@
+package images
+
+// defaultMaxCapturedOutput is how much of a command's output is kept in memory for error hints
+const defaultMaxCapturedOutput = 64 << 10
+
+// tailBuffer keeps only the last max bytes written to it
+type tailBuffer struct {
+	buf []byte
+	max int
+}
+
+func newTailBuffer(max int) *tailBuffer {
+	return &tailBuffer{max: max}
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(p) >= t.max {
+		t.buf = append(t.buf[:0], p[len(p)-t.max:]...)
+		return n, nil
+	}
+
+	if overflow := len(t.buf) + len(p) - t.max; overflow > 0 {
+		t.buf = append(t.buf[:0], t.buf[overflow:]...)
+	}
+	t.buf = append(t.buf, p...)
+	return n, nil
+}
+
+func (t *tailBuffer) Bytes() []byte {
+	return t.buf
+}
+
+// commandLogWriter streams command output to the build log file while keeping its tail.
+// Output is not sent to the app logger, which would index every line.
+type commandLogWriter struct {
+	l    *LXCBuilder
+	tail *tailBuffer
+}
+
+func (w *commandLogWriter) Write(p []byte) (int, error) {
+	w.l.writeLog(string(p))
+	return w.tail.Write(p)
+}
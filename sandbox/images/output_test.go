@@ -0,0 +1,51 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTailBufferKeepsLastBytes(t *testing.T) {
+	tail := newTailBuffer(8)
+	for _, chunk := range []string{"abc", "defgh", "ijk", strings.Repeat("z", 20) + "12345678", "9"} {
+		tail.Write([]byte(chunk))
+		if len(tail.Bytes()) > 8 {
+			t.Fatalf("tail holds %d bytes, cap is 8", len(tail.Bytes()))
+		}
+	}
+	if got := string(tail.Bytes()); got != "23456789" {
+		t.Errorf("tail = %q, want %q", got, "23456789")
+	}
+}
+
+func TestRunCommandCapsCapturedOutput(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.MaxCapturedOutput = 1 << 10
+	builder.MaxLogBytes = 0
+	logPath := builder.LogFile.Name()
+
+	// 256 KiB of filler followed by a line the error hint is taken from
+	script := `head -c 262144 /dev/zero | tr '\0' 'a'; echo; echo "No space left on device"; exit 3`
+	err := builder.runCommand("sh", "-c", script)
+
+	var provisionErr *ProvisionError
+	if !errors.As(err, &provisionErr) {
+		t.Fatalf("runCommand() error = %v, want a ProvisionError", err)
+	}
+	if provisionErr.ExitCode != 3 || provisionErr.Hint != "free up disk space in the build directory" {
+		t.Errorf("ProvisionError = %+v, want exit 3 with the disk space hint", provisionErr)
+	}
+
+	// The log file still receives the full output
+	builder.Close()
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(content, []byte("a")); n < 262144 {
+		t.Errorf("log holds %d bytes of output, want all 262144", n)
+	}
+}
@@ -0,0 +1,41 @@
+package images
+
+import (
+	"os"
+	"strings"
+)
+
+// proxyFromEnv reads a proxy setting from the host, preferring the lowercase form
+func proxyFromEnv(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return os.Getenv(strings.ToUpper(name))
+}
+
+// proxyEnv returns the proxy variables to set for in-container commands, in
+// both cases since tools disagree on which they read
+func (l *LXCBuilder) proxyEnv() []string {
+	var env []string
+	for _, v := range []struct{ name, value string }{
+		{"http_proxy", l.HTTPProxy},
+		{"https_proxy", l.HTTPSProxy},
+		{"no_proxy", l.NoProxy},
+	} {
+		if v.value == "" {
+			continue
+		}
+		env = append(env, v.name+"="+v.value, strings.ToUpper(v.name)+"="+v.value)
+	}
+	return env
+}
+
+// attachArgs builds lxc-attach arguments running command in the container with the proxy settings
+func (l *LXCBuilder) attachArgs(containerName string, command ...string) []string {
+	args := []string{"-n", containerName, "-P", l.ContainerDir}
+	for _, env := range l.proxyEnv() {
+		args = append(args, "--set-var", env)
+	}
+	args = append(args, "--")
+	return append(args, command...)
+}
@@ -0,0 +1,74 @@
+package images
+
+import (
+	"context"
+	"os/exec"
+	"slices"
+	"testing"
+)
+
+func TestAttachArgsSetsProxyVariables(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.HTTPProxy = "http://proxy.internal:3128"
+	builder.HTTPSProxy = "http://proxy.internal:3129"
+	builder.NoProxy = "localhost,10.0.3.0/24"
+
+	got := builder.attachArgs("vm-a", "apt-get", "update")
+	want := []string{
+		"-n", "vm-a", "-P", builder.ContainerDir,
+		"--set-var", "http_proxy=http://proxy.internal:3128",
+		"--set-var", "HTTP_PROXY=http://proxy.internal:3128",
+		"--set-var", "https_proxy=http://proxy.internal:3129",
+		"--set-var", "HTTPS_PROXY=http://proxy.internal:3129",
+		"--set-var", "no_proxy=localhost,10.0.3.0/24",
+		"--set-var", "NO_PROXY=localhost,10.0.3.0/24",
+		"--", "apt-get", "update",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("attachArgs() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestAttachArgsWithoutProxy(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.HTTPProxy, builder.HTTPSProxy, builder.NoProxy = "", "http://proxy.internal:3129", ""
+
+	got := builder.attachArgs("vm-a", "true")
+	want := []string{
+		"-n", "vm-a", "-P", builder.ContainerDir,
+		"--set-var", "https_proxy=http://proxy.internal:3129",
+		"--set-var", "HTTPS_PROXY=http://proxy.internal:3129",
+		"--", "true",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("attachArgs() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestProxyFromEnv(t *testing.T) {
+	t.Setenv("http_proxy", "")
+	t.Setenv("HTTP_PROXY", "http://upper:3128")
+	if got := proxyFromEnv("http_proxy"); got != "http://upper:3128" {
+		t.Errorf("proxyFromEnv() = %q, want the uppercase setting", got)
+	}
+
+	t.Setenv("http_proxy", "http://lower:3128")
+	if got := proxyFromEnv("http_proxy"); got != "http://lower:3128" {
+		t.Errorf("proxyFromEnv() = %q, want the lowercase setting first", got)
+	}
+}
+
+func TestRunStepPassesProxyToContainer(t *testing.T) {
+	builder := newTestBuilder(t)
+	builder.HTTPProxy, builder.HTTPSProxy, builder.NoProxy = "http://proxy.internal:3128", "", ""
+	commands := stubCommands(t, func(context.Context, string, []string) *exec.Cmd { return nil })
+
+	if err := builder.runStep("vm-a", RunStep{Name: "update", Args: []string{"apt-get", "update"}}); err != nil {
+		t.Fatalf("runStep() error = %v", err)
+	}
+	want := "lxc-attach -n vm-a -P " + builder.ContainerDir +
+		" --set-var http_proxy=http://proxy.internal:3128 --set-var HTTP_PROXY=http://proxy.internal:3128 -- apt-get update"
+	if got := commands.commands(); !slices.Equal(got, []string{want}) {
+		t.Errorf("commands = %q, want [%q]", got, want)
+	}
+}
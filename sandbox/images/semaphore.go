@@ -0,0 +1,38 @@
+package images
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// defaultMaxConcurrentBuilds bounds how many resource-heavy builds run at once
+const defaultMaxConcurrentBuilds = 2
+
+var (
+	buildSemaphoreMu sync.Mutex
+	buildSemaphore   = semaphore.NewWeighted(defaultMaxConcurrentBuilds)
+)
+
+// SetMaxConcurrentBuilds changes the build concurrency limit; call it before any build starts
+func SetMaxConcurrentBuilds(n int64) {
+	if n < 1 {
+		n = 1
+	}
+	buildSemaphoreMu.Lock()
+	defer buildSemaphoreMu.Unlock()
+	buildSemaphore = semaphore.NewWeighted(n)
+}
+
+// acquireBuildSlot blocks until a build slot is free and returns its release function
+func acquireBuildSlot(ctx context.Context) (func(), error) {
+	buildSemaphoreMu.Lock()
+	sem := buildSemaphore
+	buildSemaphoreMu.Unlock()
+
+	if err := sem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { sem.Release(1) }, nil
+}
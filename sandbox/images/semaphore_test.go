@@ -0,0 +1,102 @@
+package images
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cynxees/ra-server/internal/logger"
+)
+
+// stubBuildEnvironment runs builds from a temporary directory with plenty of
+// disk space and a silent logger
+func stubBuildEnvironment(t *testing.T) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	previousWriter := logger.SetWriter(func(context.Context, logger.Level, string) {})
+	previousFree := freeDiskSpace
+	freeDiskSpace = func(string) (uint64, error) { return 1 << 40, nil }
+	t.Cleanup(func() {
+		logger.SetWriter(previousWriter)
+		freeDiskSpace = previousFree
+	})
+}
+
+func TestBuildsRespectConcurrencyLimit(t *testing.T) {
+	stubBuildEnvironment(t)
+	SetMaxConcurrentBuilds(2)
+	t.Cleanup(func() { SetMaxConcurrentBuilds(defaultMaxConcurrentBuilds) })
+
+	const builds = 5
+	var mu sync.Mutex
+	running, peak := 0, 0
+	started := make(chan struct{}, builds)
+	release := make(chan struct{})
+	stubCommands(t, func(ctx context.Context, name string, args []string) *exec.Cmd {
+		switch name {
+		case "tar":
+			return exec.CommandContext(ctx, name, args...)
+		case "lxc-start":
+			mu.Lock()
+			running++
+			peak = max(peak, running)
+			mu.Unlock()
+
+			started <- struct{}{}
+			<-release
+
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}
+		return nil
+	})
+
+	build := containerBuild{
+		parent:     "ubuntu-base",
+		layerPaths: []string{"opt/app"},
+		build: func(l *LXCBuilder, containerName, _ string) error {
+			appPath := filepath.Join(l.ContainerDir, containerName, "rootfs", "opt", "app")
+			if err := os.MkdirAll(appPath, 0755); err != nil {
+				return err
+			}
+			return l.runCommand("lxc-start", "-n", containerName, "-P", l.ContainerDir, "-d")
+		},
+	}
+
+	errs := make([]error, builds)
+	var wg sync.WaitGroup
+	for i := range builds {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = runContainerBuild(context.Background(), fmt.Sprintf("app-%d", i), build, BuildOptions{})
+		}()
+	}
+
+	// Two builds take the slots; the rest wait for one to finish
+	<-started
+	<-started
+	select {
+	case <-started:
+		t.Error("a third build started while two were running")
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("build %d: %v", i, err)
+		}
+	}
+	if peak != 2 {
+		t.Errorf("peak concurrent builds = %d, want 2", peak)
+	}
+}
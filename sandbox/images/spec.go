@@ -0,0 +1,86 @@
+package images
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ContainerSpec selects the distribution image lxc-create's download template fetches
+type ContainerSpec struct {
+	Dist    string
+	Release string
+	Arch    string
+}
+
+// defaultContainerSpec is Ubuntu 22.04 on amd64
+var defaultContainerSpec = ContainerSpec{Dist: "ubuntu", Release: "jammy", Arch: "amd64"}
+
+// supportedReleases lists the download template releases the setup scripts are known to work with
+var supportedReleases = map[string][]string{
+	"ubuntu": {"focal", "jammy", "noble"},
+	"debian": {"bullseye", "bookworm"},
+}
+
+// supportedArches lists the download template architectures
+var supportedArches = []string{"amd64", "arm64", "armhf", "i386", "ppc64el", "s390x"}
+
+// archiveHosts is resolved by the setup script to check DNS before apt-get runs
+var archiveHosts = map[string]string{
+	"ubuntu": "archive.ubuntu.com",
+	"debian": "deb.debian.org",
+}
+
+// Validate rejects combinations the download template or the setup scripts cannot handle
+func (s ContainerSpec) Validate() error {
+	releases, ok := supportedReleases[s.Dist]
+	if !ok {
+		return fmt.Errorf("unsupported distribution %q", s.Dist)
+	}
+	if !slices.Contains(releases, s.Release) {
+		return fmt.Errorf("unsupported %s release %q, expected one of %v", s.Dist, s.Release, releases)
+	}
+	if !slices.Contains(supportedArches, s.Arch) {
+		return fmt.Errorf("unsupported architecture %q, expected one of %v", s.Arch, supportedArches)
+	}
+	return nil
+}
+
+// String names the spec as used in archive names, e.g. debian-bookworm-arm64
+func (s ContainerSpec) String() string {
+	return fmt.Sprintf("%s-%s-%s", s.Dist, s.Release, s.Arch)
+}
+
+// createArgs returns the download template arguments passed after -- to lxc-create
+func (s ContainerSpec) createArgs() []string {
+	return []string{"--dist", s.Dist, "--release", s.Release, "--arch", s.Arch}
+}
+
+// archiveHost returns the package archive host of the distribution
+func (s ContainerSpec) archiveHost() string {
+	return archiveHosts[s.Dist]
+}
+
+// rootfsMarkers are files every supported template's rootfs contains once fully downloaded
+var rootfsMarkers = []string{"bin/bash", "etc/os-release"}
+
+// verifyRootfs checks that a freshly created container's rootfs holds the
+// rootfsMarkers, returning ErrTemplateIncomplete naming the ones missing
+func verifyRootfs(rootfsPath string) error {
+	if info, err := os.Stat(rootfsPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("%w: rootfs %s does not exist", ErrTemplateIncomplete, rootfsPath)
+	}
+
+	var missing []string
+	for _, marker := range rootfsMarkers {
+		if _, err := os.Lstat(filepath.Join(rootfsPath, marker)); err != nil {
+			missing = append(missing, "/"+marker)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s missing from %s", ErrTemplateIncomplete, strings.Join(missing, ", "), rootfsPath)
+	}
+	return nil
+}
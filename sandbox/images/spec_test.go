@@ -0,0 +1,87 @@
+package images
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeRootfs creates rootfs holding the given files
+func writeRootfs(t *testing.T, rootfs string, files ...string) {
+	t.Helper()
+	if err := os.MkdirAll(rootfs, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		path := filepath.Join(rootfs, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestVerifyRootfs(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       []string
+		wantMissing []string
+	}{
+		{name: "complete", files: []string{"bin/bash", "etc/os-release"}},
+		{name: "empty", wantMissing: []string{"/bin/bash", "/etc/os-release"}},
+		{name: "partial", files: []string{"etc/os-release"}, wantMissing: []string{"/bin/bash"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootfs := filepath.Join(t.TempDir(), "rootfs")
+			writeRootfs(t, rootfs, tt.files...)
+
+			err := verifyRootfs(rootfs)
+			if len(tt.wantMissing) == 0 {
+				if err != nil {
+					t.Errorf("verifyRootfs() error = %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrTemplateIncomplete) {
+				t.Fatalf("verifyRootfs() error = %v, want ErrTemplateIncomplete", err)
+			}
+			for _, marker := range tt.wantMissing {
+				if !strings.Contains(err.Error(), marker) {
+					t.Errorf("error %q does not name %s", err, marker)
+				}
+			}
+		})
+	}
+}
+
+func TestVerifyRootfsFollowsMergedUsr(t *testing.T) {
+	rootfs := filepath.Join(t.TempDir(), "rootfs")
+	writeRootfs(t, rootfs, "usr/bin/bash", "etc/os-release")
+	// Ubuntu links /bin to usr/bin
+	if err := os.Symlink("usr/bin", filepath.Join(rootfs, "bin")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifyRootfs(rootfs); err != nil {
+		t.Errorf("verifyRootfs() error = %v", err)
+	}
+}
+
+func TestVerifyRootfsMissingDirectory(t *testing.T) {
+	dir := t.TempDir()
+	notDir := filepath.Join(dir, "rootfs-file")
+	if err := os.WriteFile(notDir, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rootfs := range []string{filepath.Join(dir, "missing"), notDir} {
+		if err := verifyRootfs(rootfs); !errors.Is(err, ErrTemplateIncomplete) {
+			t.Errorf("verifyRootfs(%s) error = %v, want ErrTemplateIncomplete", rootfs, err)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package images
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// RunStep is a single provisioning command, run inside the container without a shell
+type RunStep struct {
+	Name string
+	Args []string
+}
+
+// ErrUnsafeStep is returned for steps rejected by sanitizeStep
+var ErrUnsafeStep = errors.New("unsafe build step")
+
+// allowedStepCommands lists the programs a RunStep may invoke. Commands that
+// delete, move or download files are left out.
+var allowedStepCommands = map[string]bool{
+	"apt-get":             true,
+	"apt":                 true,
+	"dpkg":                true,
+	"update-alternatives": true,
+	"echo":                true,
+	"mkdir":               true,
+	"touch":               true,
+	"cp":                  true,
+	"ln":                  true,
+	"chmod":               true,
+	"chown":               true,
+	"tar":                 true,
+	"useradd":             true,
+	"groupadd":            true,
+}
+
+// deniedStepFlags are flags that widen a sandbox's access to the host
+var deniedStepFlags = []string{"--privileged", "--cap-add", "--security-opt", "--device", "--pid=host", "--net=host", "--network=host"}
+
+// hostEscapePaths are paths that reach the host from inside a container
+var hostEscapePaths = []string{"/proc/1/root", "/proc/self/root", "/sys/fs/cgroup", "/var/run/docker.sock", "/run/docker.sock", "/var/lib/lxc", "/host"}
+
+// sanitizeStep rejects steps that run unlisted programs, pass privilege flags or
+// reference host paths, including the builder's own work and container directories
+func (l *LXCBuilder) sanitizeStep(step RunStep) error {
+	if len(step.Args) == 0 {
+		return fmt.Errorf("%w %q: no command", ErrUnsafeStep, step.Name)
+	}
+	if !allowedStepCommands[step.Args[0]] {
+		return fmt.Errorf("%w %q: command %q is not allowed", ErrUnsafeStep, step.Name, step.Args[0])
+	}
+
+	hostPaths := append([]string{l.WorkDir, l.ContainerDir}, hostEscapePaths...)
+	for _, arg := range step.Args[1:] {
+		for _, flag := range deniedStepFlags {
+			if arg == flag || strings.HasPrefix(arg, flag+"=") {
+				return fmt.Errorf("%w %q: flag %s is not allowed", ErrUnsafeStep, step.Name, arg)
+			}
+		}
+
+		// Check the path part of --opt=value arguments as well as bare paths
+		value := arg
+		if i := strings.Index(arg, "="); i >= 0 {
+			value = arg[i+1:]
+		}
+		if !strings.HasPrefix(value, "/") {
+			continue
+		}
+		value = filepath.Clean(value)
+		for _, hostPath := range hostPaths {
+			if hostPath != "" && (value == hostPath || strings.HasPrefix(value, hostPath+"/")) {
+				return fmt.Errorf("%w %q: path %s reaches the host", ErrUnsafeStep, step.Name, value)
+			}
+		}
+	}
+	return nil
+}
+
+// runSteps runs l.Steps in order inside the running container
+func (l *LXCBuilder) runSteps(containerName string) error {
+	for _, step := range l.Steps {
+		if err := l.runStep(containerName, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runStep sanitizes step and runs it inside the container, never on the host
+func (l *LXCBuilder) runStep(containerName string, step RunStep) error {
+	if err := l.sanitizeStep(step); err != nil {
+		return err
+	}
+
+	l.log("🔧 RUN %s", step.Name)
+	if err := l.runCommand("lxc-attach", l.attachArgs(containerName, step.Args...)...); err != nil {
+		return withStep("run step "+step.Name, err)
+	}
+	return nil
+}
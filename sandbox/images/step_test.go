@@ -0,0 +1,49 @@
+package images
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSanitizeStep(t *testing.T) {
+	builder := &LXCBuilder{WorkDir: "/srv/ra/build", ContainerDir: "/srv/ra/build/containers"}
+
+	tests := []struct {
+		name    string
+		args    []string
+		allowed bool
+	}{
+		{"in-container install", []string{"apt-get", "install", "-y", "openjdk-8-jdk"}, true},
+		{"in-container path", []string{"mkdir", "-p", "/app/data"}, true},
+		{"empty", nil, false},
+		{"remove", []string{"rm", "-rf", "/app"}, false},
+		{"move", []string{"mv", "/app", "/opt/app"}, false},
+		{"download", []string{"curl", "-o", "/app/run.sh", "http://example.com/run.sh"}, false},
+		{"work dir", []string{"cp", "/etc/hosts", "/srv/ra/build/hosts"}, false},
+		{"container dir option", []string{"tar", "--directory=/srv/ra/build/containers/other", "-cf", "/app/x.tar", "."}, false},
+		{"host escape", []string{"ln", "-s", "/proc/1/root", "/app/host"}, false},
+		{"privileged flag", []string{"apt-get", "--privileged", "update"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := builder.sanitizeStep(RunStep{Name: tt.name, Args: tt.args})
+			if tt.allowed && err != nil {
+				t.Errorf("sanitizeStep(%v) error = %v, want allowed", tt.args, err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrUnsafeStep) {
+				t.Errorf("sanitizeStep(%v) error = %v, want ErrUnsafeStep", tt.args, err)
+			}
+		})
+	}
+}
+
+func TestBuildOptionsApplySteps(t *testing.T) {
+	builder := &LXCBuilder{}
+	steps := []RunStep{{Name: "workdir", Args: []string{"mkdir", "-p", "/app"}}}
+
+	BuildOptions{Steps: steps}.apply(builder)
+	if len(builder.Steps) != 1 || builder.Steps[0].Name != "workdir" {
+		t.Errorf("Steps = %+v, want %+v", builder.Steps, steps)
+	}
+}
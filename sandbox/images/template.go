@@ -0,0 +1,81 @@
+package images
+
+import (
+	"bytes"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"text/template"
+)
+
+// lxcConfigData holds the values interpolated into the container config
+type lxcConfigData struct {
+	Bridge          string
+	HWAddr          string
+	ApparmorProfile string
+	IPv4Address     string
+	IPv4Gateway     string
+}
+
+// lxcConfigTemplate is appended to the config generated by lxc-create
+var lxcConfigTemplate = template.Must(template.New("lxc-config").Option("missingkey=error").Parse(`
+# Enable networking with veth and bridge
+lxc.net.0.type = veth
+lxc.net.0.link = {{ .Bridge }}
+lxc.net.0.flags = up
+lxc.net.0.hwaddr = {{ .HWAddr }}
+{{- if .IPv4Address }}
+lxc.net.0.ipv4.address = {{ .IPv4Address }}
+{{- end }}
+{{- if .IPv4Gateway }}
+lxc.net.0.ipv4.gateway = {{ .IPv4Gateway }}
+{{- end }}
+lxc.apparmor.profile = {{ .ApparmorProfile }}
+lxc.cap.drop = 
+`))
+
+// bridgeNamePattern matches Linux interface names: at most 15 characters, no whitespace or slashes
+var bridgeNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,15}$`)
+
+// validateBridgeName rejects names that cannot be a network interface
+func validateBridgeName(name string) error {
+	if !bridgeNamePattern.MatchString(name) || name == "." || name == ".." {
+		return fmt.Errorf("invalid bridge name %q", name)
+	}
+	return nil
+}
+
+// ValidateStaticIPv4 checks that address is an IPv4 CIDR and gateway, if set, an IPv4 address inside it
+func ValidateStaticIPv4(address, gateway string) error {
+	if address == "" {
+		if gateway != "" {
+			return fmt.Errorf("gateway %q set without a static address", gateway)
+		}
+		return nil
+	}
+
+	prefix, err := netip.ParsePrefix(address)
+	if err != nil || !prefix.Addr().Is4() {
+		return fmt.Errorf("invalid static IPv4 address %q: expected CIDR such as 10.0.3.10/24", address)
+	}
+	if gateway == "" {
+		return nil
+	}
+	gw, err := netip.ParseAddr(gateway)
+	if err != nil || !gw.Is4() {
+		return fmt.Errorf("invalid IPv4 gateway %q", gateway)
+	}
+	if !prefix.Masked().Contains(gw) {
+		return fmt.Errorf("gateway %s is outside %s", gw, prefix.Masked())
+	}
+	return nil
+}
+
+// renderTemplate executes a template with the given data and returns the output
+func renderTemplate(tmpl *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
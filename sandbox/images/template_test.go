@@ -0,0 +1,85 @@
+package images
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLXCConfigTemplateGolden(t *testing.T) {
+	tests := []struct {
+		golden string
+		data   lxcConfigData
+	}{
+		{
+			golden: "lxc-config-dhcp.golden",
+			data:   lxcConfigData{Bridge: "lxcbr0", HWAddr: "00:16:3e:xx:xx:xx", ApparmorProfile: "unconfined"},
+		},
+		{
+			golden: "lxc-config-static.golden",
+			data: lxcConfigData{
+				Bridge:          "br0",
+				HWAddr:          "00:16:3e:xx:xx:xx",
+				ApparmorProfile: "unconfined",
+				IPv4Address:     "10.0.3.10/24",
+				IPv4Gateway:     "10.0.3.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			got, err := renderTemplate(lxcConfigTemplate, tt.data)
+			if err != nil {
+				t.Fatalf("renderTemplate() error = %v", err)
+			}
+			want, err := os.ReadFile(filepath.Join("testdata", tt.golden))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != string(want) {
+				t.Errorf("rendered config differs from %s:\ngot:\n%s\nwant:\n%s", tt.golden, got, want)
+			}
+		})
+	}
+}
+
+func TestConfigureNetworkUsesBridge(t *testing.T) {
+	builder := newTestBuilder(t)
+	configPath := filepath.Join(builder.ContainerDir, "config")
+	if err := os.WriteFile(configPath, []byte("lxc.uts.name = web\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	BuildOptions{Bridge: "virbr0"}.apply(builder)
+
+	if err := builder.configureNetwork(configPath); err != nil {
+		t.Fatalf("configureNetwork() error = %v", err)
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "\nlxc.net.0.link = virbr0\n") {
+		t.Errorf("config does not link the custom bridge:\n%s", content)
+	}
+	if strings.Contains(string(content), defaultBridge) {
+		t.Errorf("config still mentions the default bridge %s:\n%s", defaultBridge, content)
+	}
+}
+
+func TestConfigureNetworkRejectsInvalidBridge(t *testing.T) {
+	builder := newTestBuilder(t)
+	configPath := filepath.Join(builder.ContainerDir, "config")
+	if err := os.WriteFile(configPath, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	builder.Bridge = "br0; reboot"
+
+	if err := builder.configureNetwork(configPath); err == nil {
+		t.Error("configureNetwork() accepted an invalid bridge name")
+	}
+	if content, _ := os.ReadFile(configPath); len(content) != 0 {
+		t.Errorf("config was written despite the invalid bridge: %q", content)
+	}
+}
@@ -0,0 +1,47 @@
+package images
+
+import (
+	"errors"
+	"os/exec"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// defaultBuildTimeout bounds a whole build, from container creation to export
+const defaultBuildTimeout = 2 * time.Hour
+
+// cleanupTimeout bounds the commands run to tidy up after a build times out
+const cleanupTimeout = time.Minute
+
+// commandWaitDelay bounds how long a killed command may keep its output pipes open
+const commandWaitDelay = 10 * time.Second
+
+// ErrBuildTimeout is returned when a build exceeds its overall timeout
+var ErrBuildTimeout = errors.New("build timed out")
+
+var buildTimeout atomic.Int64
+
+func init() {
+	buildTimeout.Store(int64(defaultBuildTimeout))
+}
+
+// SetBuildTimeout changes the overall build timeout; zero or less disables it
+func SetBuildTimeout(d time.Duration) {
+	buildTimeout.Store(int64(d))
+}
+
+func currentBuildTimeout() time.Duration {
+	return time.Duration(buildTimeout.Load())
+}
+
+// killOnCancel runs cmd in its own process group and kills the whole group
+// when its context is cancelled, so processes it spawned, e.g. apt-get under
+// lxc-attach, do not outlive the build
+func killOnCancel(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = commandWaitDelay
+}
@@ -0,0 +1,97 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// processRunning reports whether pid is alive; a killed process waiting to be
+// reaped counts as gone
+func processRunning(pid int) bool {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return syscall.Kill(pid, 0) == nil
+	}
+	// The state follows the parenthesised command name
+	_, fields, _ := strings.Cut(string(stat), ") ")
+	return !strings.HasPrefix(fields, "Z") && !strings.HasPrefix(fields, "X")
+}
+
+func TestRunCommandKillsSlowCommandOnTimeout(t *testing.T) {
+	builder := newTestBuilder(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	builder.ctx = ctx
+
+	// The shell starts a child of its own, which must die with it
+	pidFile := filepath.Join(t.TempDir(), "child.pid")
+	start := time.Now()
+	err := builder.runCommand("sh", "-c", "sleep 30 & echo $! > "+pidFile+"; wait")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("runCommand() error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("runCommand() returned after %s, want it killed at the timeout", elapsed)
+	}
+
+	content, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); processRunning(pid); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("child process %d outlived the timeout", pid)
+		}
+	}
+}
+
+func TestBuildTimeoutAbortsBuild(t *testing.T) {
+	stubBuildEnvironment(t)
+	SetBuildTimeout(200 * time.Millisecond)
+	t.Cleanup(func() { SetBuildTimeout(defaultBuildTimeout) })
+	commands := stubCommands(t, func(ctx context.Context, name string, args []string) *exec.Cmd {
+		if name == "sleep" {
+			return exec.CommandContext(ctx, name, args...)
+		}
+		return nil
+	})
+
+	build := containerBuild{
+		build: func(l *LXCBuilder, containerName, _ string) error {
+			return l.runCommand("sleep", "30")
+		},
+	}
+	start := time.Now()
+	_, err := runContainerBuild(context.Background(), "slow", build, BuildOptions{})
+	if !errors.Is(err, ErrBuildTimeout) {
+		t.Fatalf("runContainerBuild() error = %v, want ErrBuildTimeout", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("build returned after %s, want it aborted at the timeout", elapsed)
+	}
+
+	// The timed-out container is stopped with a context of its own
+	stopped := false
+	for _, command := range commands.commands() {
+		if strings.HasPrefix(command, "lxc-stop -n slow-") && strings.HasSuffix(command, " -k") {
+			stopped = true
+		}
+	}
+	if !stopped {
+		t.Errorf("commands = %q, want the container force-stopped", commands.commands())
+	}
+}
@@ -0,0 +1,72 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cynxees/ra-server/internal/model/entity"
+)
+
+// LaunchOpts holds the host-side settings for starting a VM
+type LaunchOpts struct {
+	// DiskImage is the qcow2 image the VM boots from
+	DiskImage string
+	// MACAddress is optional; QEMU picks one when empty
+	MACAddress string
+	// KVM enables hardware acceleration and host CPU passthrough
+	KVM bool
+	// Headless disables the display and serial console window
+	Headless bool
+}
+
+// escapeOptionValue doubles commas, which QEMU otherwise reads as the start of
+// another option
+func escapeOptionValue(value string) string {
+	return strings.ReplaceAll(value, ",", ",,")
+}
+
+// BuildQEMUArgs turns a VM and its resources into qemu-system-x86_64 arguments.
+// The VM's port, when set, is forwarded to guest SSH.
+func BuildQEMUArgs(vm *entity.VirtualMachine, res entity.VMResources, opts LaunchOpts) ([]string, error) {
+	if vm == nil {
+		return nil, errors.New("virtual machine is required")
+	}
+	if opts.DiskImage == "" {
+		return nil, errors.New("disk image is required")
+	}
+	if err := res.Validate(); err != nil {
+		return nil, err
+	}
+	if strings.Contains(opts.DiskImage, ",") {
+		return nil, fmt.Errorf("disk image path %q must not contain commas", opts.DiskImage)
+	}
+
+	args := []string{"-name", escapeOptionValue(vm.Name)}
+	if opts.KVM {
+		args = append(args, "-machine", "q35,accel=kvm", "-cpu", "host")
+	} else {
+		args = append(args, "-machine", "q35")
+	}
+	args = append(args,
+		"-smp", strconv.Itoa(int(res.CPUs)),
+		"-m", strconv.Itoa(int(res.MemoryMB)),
+		"-drive", "file="+opts.DiskImage+",if=virtio,format=qcow2",
+	)
+
+	netdev := "user,id=net0"
+	if vm.Port > 0 {
+		netdev += fmt.Sprintf(",hostfwd=tcp::%d-:22", vm.Port)
+	}
+	device := "virtio-net-pci,netdev=net0"
+	if opts.MACAddress != "" {
+		device += ",mac=" + opts.MACAddress
+	}
+	args = append(args, "-netdev", netdev, "-device", device)
+
+	if opts.Headless {
+		args = append(args, "-display", "none", "-serial", "none")
+	}
+	return args, nil
+}
@@ -0,0 +1,117 @@
+package vm
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cynxees/ra-server/internal/model/entity"
+)
+
+func TestBuildQEMUArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		vm   *entity.VirtualMachine
+		res  entity.VMResources
+		opts LaunchOpts
+		want []string
+	}{
+		{
+			name: "minimal",
+			vm:   &entity.VirtualMachine{Name: "vm-a"},
+			res:  entity.VMResources{CPUs: 1, MemoryMB: 256, DiskGB: 1},
+			opts: LaunchOpts{DiskImage: "/images/vm-a.qcow2"},
+			want: []string{
+				"-name", "vm-a",
+				"-machine", "q35",
+				"-smp", "1", "-m", "256",
+				"-drive", "file=/images/vm-a.qcow2,if=virtio,format=qcow2",
+				"-netdev", "user,id=net0",
+				"-device", "virtio-net-pci,netdev=net0",
+			},
+		},
+		{
+			name: "kvm with ssh forward",
+			vm:   &entity.VirtualMachine{Name: "build-box", Port: 2222},
+			res:  entity.VMResources{CPUs: 8, MemoryMB: 16384, DiskGB: 100},
+			opts: LaunchOpts{DiskImage: "/images/build.qcow2", KVM: true, MACAddress: "52:54:00:12:34:56"},
+			want: []string{
+				"-name", "build-box",
+				"-machine", "q35,accel=kvm", "-cpu", "host",
+				"-smp", "8", "-m", "16384",
+				"-drive", "file=/images/build.qcow2,if=virtio,format=qcow2",
+				"-netdev", "user,id=net0,hostfwd=tcp::2222-:22",
+				"-device", "virtio-net-pci,netdev=net0,mac=52:54:00:12:34:56",
+			},
+		},
+		{
+			name: "headless at the upper bounds",
+			vm:   &entity.VirtualMachine{Name: "big"},
+			res:  entity.VMResources{CPUs: 32, MemoryMB: 64 * 1024, DiskGB: 1024},
+			opts: LaunchOpts{DiskImage: "big.qcow2", Headless: true},
+			want: []string{
+				"-name", "big",
+				"-machine", "q35",
+				"-smp", "32", "-m", "65536",
+				"-drive", "file=big.qcow2,if=virtio,format=qcow2",
+				"-netdev", "user,id=net0",
+				"-device", "virtio-net-pci,netdev=net0",
+				"-display", "none", "-serial", "none",
+			},
+		},
+		{
+			name: "comma in name",
+			vm:   &entity.VirtualMachine{Name: "web,process=evil"},
+			res:  entity.VMResources{CPUs: 2, MemoryMB: 2048, DiskGB: 20},
+			opts: LaunchOpts{DiskImage: "web.qcow2"},
+			want: []string{
+				"-name", "web,,process=evil",
+				"-machine", "q35",
+				"-smp", "2", "-m", "2048",
+				"-drive", "file=web.qcow2,if=virtio,format=qcow2",
+				"-netdev", "user,id=net0",
+				"-device", "virtio-net-pci,netdev=net0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildQEMUArgs(tt.vm, tt.res, tt.opts)
+			if err != nil {
+				t.Fatalf("BuildQEMUArgs() error = %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("BuildQEMUArgs() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildQEMUArgsRejects(t *testing.T) {
+	vm := &entity.VirtualMachine{Name: "vm-a"}
+	res := entity.VMResources{CPUs: 2, MemoryMB: 2048, DiskGB: 20}
+	opts := LaunchOpts{DiskImage: "vm-a.qcow2"}
+
+	tests := []struct {
+		name      string
+		vm        *entity.VirtualMachine
+		res       entity.VMResources
+		opts      LaunchOpts
+		wantError string
+	}{
+		{name: "no vm", vm: nil, res: res, opts: opts, wantError: "virtual machine is required"},
+		{name: "no disk image", vm: vm, res: res, opts: LaunchOpts{}, wantError: "disk image is required"},
+		{name: "comma in disk image", vm: vm, res: res, opts: LaunchOpts{DiskImage: "a,format=raw"}, wantError: "must not contain commas"},
+		{name: "zero cpus", vm: vm, res: entity.VMResources{MemoryMB: 2048, DiskGB: 20}, opts: opts, wantError: "cpus"},
+		{name: "too much memory", vm: vm, res: entity.VMResources{CPUs: 2, MemoryMB: 1 << 20, DiskGB: 20}, opts: opts, wantError: "memory_mb"},
+		{name: "zero disk", vm: vm, res: entity.VMResources{CPUs: 2, MemoryMB: 2048}, opts: opts, wantError: "disk_gb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := BuildQEMUArgs(tt.vm, tt.res, tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("BuildQEMUArgs() = %q, %v, want error %q", args, err, tt.wantError)
+			}
+		})
+	}
+}